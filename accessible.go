@@ -0,0 +1,75 @@
+package color
+
+import (
+	"errors"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+var ErrContrastUnreachable = errors.New("color: target contrast is unreachable")
+
+/******************
+	CONTRAST
+*******************/
+
+// BestContrast returns the candidate with the highest WCAG contrast against
+// bg, choosing between Black and White when no candidates are given.
+func BestContrast(bg ColorFA, candidates ...ColorFA) (best ColorFA, ratio float32) {
+	if len(candidates) == 0 {
+		candidates = []ColorFA{Black, White}
+	}
+	for i, c := range candidates {
+		if r := CheckContrast(c, bg).Ratio; i == 0 || r > ratio {
+			best, ratio = c, r
+		}
+	}
+	return best, ratio
+}
+
+// BestContrastAPCA is BestContrast using the magnitude of APCA Lc.
+func BestContrastAPCA(bg ColorFA, candidates ...ColorFA) (best ColorFA, lc float32) {
+	if len(candidates) == 0 {
+		candidates = []ColorFA{Black, White}
+	}
+	for i, c := range candidates {
+		if l := APCAContrast(c, bg); i == 0 || math.Abs(l) > math.Abs(lc) {
+			best, lc = c, l
+		}
+	}
+	return best, lc
+}
+
+// AdjustForContrast changes only the Oklab lightness of fg, by as little as
+// possible, until it reaches the target WCAG contrast ratio against bg.
+// Hue is kept and chroma is reduced only where sRGB requires it.
+func AdjustForContrast(fg ColorFA, bg ColorFA, target float32) (ColorFA, error) {
+	meets := func(c ColorFA) bool {
+		return CheckContrast(c, bg).Ratio >= target
+	}
+	if meets(fg) {
+		return fg, nil
+	}
+	start := fg.OkLightness()
+	best, bestDelta, found := fg, float32(0), false
+	for _, end := range [2]float32{0, 1} {
+		if !meets(fg.SetOkLightness(end)) {
+			continue
+		}
+		lo, hi := start, end
+		for i := 0; i < 24; i += 1 {
+			mid := (lo + hi) / 2
+			if meets(fg.SetOkLightness(mid)) {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		if delta := math.Abs(hi - start); !found || delta < bestDelta {
+			best, bestDelta, found = fg.SetOkLightness(hi), delta, true
+		}
+	}
+	if !found {
+		return fg, ErrContrastUnreachable
+	}
+	return best, nil
+}
@@ -0,0 +1,120 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// Constants from the APCA-W3 0.0.98G-4g reference implementation.
+const (
+	apcaMainTRC     = 2.4
+	apcaNormBG      = 0.56
+	apcaNormTXT     = 0.57
+	apcaRevTXT      = 0.62
+	apcaRevBG       = 0.65
+	apcaBlkThrs     = 0.022
+	apcaBlkClmp     = 1.414
+	apcaScaleBoW    = 1.14
+	apcaScaleWoB    = 1.14
+	apcaLoBoWOffset = 0.027
+	apcaLoWoBOffset = 0.027
+	apcaDeltaYMin   = 0.0005
+	apcaLoClip      = 0.1
+
+	apcaProhibited = 999
+	apcaNonText    = 777
+)
+
+/******************
+	COLOR_FA
+*******************/
+
+// APCAContrast returns the APCA lightness contrast (Lc) of text on bg, about
+// -108 to 106. Positive values are dark text on a light background, negative
+// values light text on a dark background. Translucent text is composited
+// over bg first and bg is treated as opaque.
+func APCAContrast(text ColorFA, bg ColorFA) float32 {
+	bg = bg.SetAlpha(maxF)
+	yTxt := apcaClamp(bg.SrcOver(text).apcaY())
+	yBg := apcaClamp(bg.apcaY())
+	if math.Abs(yBg-yTxt) < apcaDeltaYMin {
+		return 0
+	}
+	if yBg > yTxt {
+		sapc := (math.Pow(yBg, apcaNormBG) - math.Pow(yTxt, apcaNormTXT)) * apcaScaleBoW
+		if sapc < apcaLoClip {
+			return 0
+		}
+		return (sapc - apcaLoBoWOffset) * 100
+	}
+	sapc := (math.Pow(yBg, apcaRevBG) - math.Pow(yTxt, apcaRevTXT)) * apcaScaleWoB
+	if sapc > -apcaLoClip {
+		return 0
+	}
+	return (sapc + apcaLoWoBOffset) * 100
+}
+
+// APCAMinFontSize returns the smallest font size in CSS px that the APCA
+// font lookup table allows for a contrast and font weight (100-900). The
+// table is read conservatively: Lc rounds down to its 5-point row and weight
+// rounds down to its hundred. ok is false when no text size is acceptable
+// or lc is NaN.
+func APCAMinFontSize(lc float32, weight int) (size float32, ok bool) {
+	if lc != lc {
+		return 0, false
+	}
+	row := int(math.Min(math.Abs(lc)/5, float32(len(apcaFontTable)-1)))
+	col := math.Clamp(0, (weight/100)-1, 8)
+	size = apcaFontTable[row][col]
+	if size >= apcaNonText {
+		return 0, false
+	}
+	return size, true
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func (c ColorFA) apcaY() float32 {
+	cc := c.Clamp()
+	return (math.Pow(cc[0], apcaMainTRC) * 0.2126729) +
+		(math.Pow(cc[1], apcaMainTRC) * 0.7151522) +
+		(math.Pow(cc[2], apcaMainTRC) * 0.0721750)
+}
+
+func apcaClamp(y float32) float32 {
+	if y > apcaBlkThrs {
+		return y
+	}
+	return y + math.Pow(apcaBlkThrs-y, apcaBlkClmp)
+}
+
+// apcaFontTable rows step Lc by 5 from 0; columns are weights 100-900.
+var apcaFontTable = [...][9]float32{
+	{999, 999, 999, 999, 999, 999, 999, 999, 999},
+	{999, 999, 999, 999, 999, 999, 999, 999, 999},
+	{999, 999, 999, 999, 999, 999, 999, 999, 999},
+	{777, 777, 777, 777, 777, 777, 777, 777, 777},
+	{777, 777, 777, 777, 777, 777, 777, 777, 777},
+	{777, 777, 777, 120, 120, 108, 96, 96, 96},
+	{777, 777, 120, 108, 108, 96, 72, 72, 72},
+	{777, 120, 108, 96, 72, 60, 48, 48, 48},
+	{120, 108, 96, 60, 48, 42, 32, 32, 32},
+	{108, 96, 72, 42, 32, 28, 24, 24, 24},
+	{96, 72, 60, 32, 28, 24, 21, 21, 21},
+	{80, 60, 48, 28, 24, 21, 18, 18, 18},
+	{72, 48, 42, 24, 21, 18, 16, 16, 18},
+	{68, 46, 32, 21.75, 19, 17, 15, 16, 18},
+	{64, 44, 28, 19.5, 18, 16, 14.5, 16, 18},
+	{60, 42, 24, 18, 16, 15, 14, 16, 18},
+	{56, 38.25, 23, 17.25, 15.81, 14.81, 14, 16, 18},
+	{52, 34.5, 22, 16.5, 15.625, 14.625, 14, 16, 18},
+	{48, 32, 21, 16, 15.5, 14.5, 14, 16, 18},
+	{45, 28, 19.5, 15.5, 15, 14, 13.5, 16, 18},
+	{42, 26.5, 18.5, 15, 14.5, 13.5, 13, 16, 18},
+	{39, 25, 18, 14.5, 14, 13, 12, 16, 18},
+	{36, 24, 18, 14, 13, 12, 11, 16, 18},
+	{34.5, 22.5, 17.25, 12.5, 11.875, 11.25, 10.625, 14.5, 16.5},
+	{33, 21, 16.5, 11, 10.75, 10.5, 10.25, 13, 15},
+	{32, 20, 16, 10, 10, 10, 10, 12, 14},
+}
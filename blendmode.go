@@ -0,0 +1,508 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	ModeNormal BlendMode = iota
+	ModeMultiply
+	ModeScreen
+	ModeOverlay
+	ModeDarken
+	ModeLighten
+	ModeColorDodge
+	ModeColorBurn
+	ModeHardLight
+	ModeSoftLight
+	ModeDifference
+	ModeExclusion
+	ModeLinearBurn
+	ModeLinearDodge
+	ModeLinearLight
+	ModeVividLight
+	ModePinLight
+	ModeHardMix
+	ModeSubtract
+	ModeDivide
+	ModeDarkerColor
+	ModeLighterColor
+	ModeHue
+	ModeSaturation
+	ModeColor
+	ModeLuminosity
+	ModeClassicOverlay
+	ModeClassicHardLight
+	ModeClassicSoftLight
+	ModeClassicVividLight
+	modeCount
+)
+
+// BlendMode names one of the Blend* modes so it can be chosen at runtime.
+// Its text form is the CSS mix-blend-mode keyword where one exists.
+type BlendMode uint8
+
+/******************
+	COLOR_FA
+*******************/
+
+// The Blend* methods follow W3C Compositing and Blending Level 1: the
+// receiver is the backdrop, the argument is the source, the mode's result is
+// mixed into the source by backdrop alpha, and the outcome is composited
+// source-over. Overlay, HardLight, SoftLight and VividLight in color.go use
+// different formulas and ignore alpha; the BlendClassic* methods run those
+// through the same compositing.
+
+func (c ColorFA) BlendNormal(src ColorFA) ColorFA {
+	return c.blendSeparable(src, normalB)
+}
+func (c ColorFA) BlendMultiply(src ColorFA) ColorFA {
+	return c.blendSeparable(src, multiplyB)
+}
+func (c ColorFA) BlendScreen(src ColorFA) ColorFA {
+	return c.blendSeparable(src, screenB)
+}
+func (c ColorFA) BlendOverlay(src ColorFA) ColorFA {
+	return c.blendSeparable(src, overlayB)
+}
+func (c ColorFA) BlendDarken(src ColorFA) ColorFA {
+	return c.blendSeparable(src, math.Min[float32])
+}
+func (c ColorFA) BlendLighten(src ColorFA) ColorFA {
+	return c.blendSeparable(src, math.Max[float32])
+}
+func (c ColorFA) BlendColorDodge(src ColorFA) ColorFA {
+	return c.blendSeparable(src, colorDodgeB)
+}
+func (c ColorFA) BlendColorBurn(src ColorFA) ColorFA {
+	return c.blendSeparable(src, colorBurnB)
+}
+func (c ColorFA) BlendHardLight(src ColorFA) ColorFA {
+	return c.blendSeparable(src, hardLightB)
+}
+func (c ColorFA) BlendSoftLight(src ColorFA) ColorFA {
+	return c.blendSeparable(src, softLightB)
+}
+func (c ColorFA) BlendDifference(src ColorFA) ColorFA {
+	return c.blendSeparable(src, differenceB)
+}
+func (c ColorFA) BlendExclusion(src ColorFA) ColorFA {
+	return c.blendSeparable(src, exclusionB)
+}
+func (c ColorFA) BlendLinearBurn(src ColorFA) ColorFA {
+	return c.blendSeparable(src, linearBurnB)
+}
+func (c ColorFA) BlendLinearDodge(src ColorFA) ColorFA {
+	return c.blendSeparable(src, linearDodgeB)
+}
+func (c ColorFA) BlendLinearLight(src ColorFA) ColorFA {
+	return c.blendSeparable(src, linearLightB)
+}
+func (c ColorFA) BlendVividLight(src ColorFA) ColorFA {
+	return c.blendSeparable(src, vividLightB)
+}
+func (c ColorFA) BlendPinLight(src ColorFA) ColorFA {
+	return c.blendSeparable(src, pinLightB)
+}
+func (c ColorFA) BlendHardMix(src ColorFA) ColorFA {
+	return c.blendSeparable(src, hardMixB)
+}
+func (c ColorFA) BlendSubtract(src ColorFA) ColorFA {
+	return c.blendSeparable(src, subtractB)
+}
+func (c ColorFA) BlendDivide(src ColorFA) ColorFA {
+	return c.blendSeparable(src, divideB)
+}
+func (c ColorFA) BlendHue(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, hueB)
+}
+func (c ColorFA) BlendSaturation(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, saturationB)
+}
+func (c ColorFA) BlendColor(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, colorB)
+}
+func (c ColorFA) BlendLuminosity(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, luminosityB)
+}
+
+func (c ColorFA) BlendDarkerColor(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, darkerColorB)
+}
+func (c ColorFA) BlendLighterColor(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, lighterColorB)
+}
+
+func (c ColorFA) BlendClassicOverlay(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, classicB(ColorFA.Overlay))
+}
+func (c ColorFA) BlendClassicHardLight(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, classicB(ColorFA.HardLight))
+}
+func (c ColorFA) BlendClassicSoftLight(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, classicB(ColorFA.SoftLight))
+}
+func (c ColorFA) BlendClassicVividLight(src ColorFA) ColorFA {
+	return c.blendNonSeparable(src, classicVividLightB)
+}
+
+// Composite blends src onto dst with the given mode after scaling src alpha
+// by opacity. Unknown modes fall back to ModeNormal.
+func Composite(dst ColorFA, src ColorFA, mode BlendMode, opacity float32) ColorFA {
+	src[3] *= math.Clamp(minF, opacity, maxF)
+	return mode.Func()(dst, src)
+}
+
+/******************
+	BLEND_MODE
+*******************/
+
+func ParseBlendMode(name string) (BlendMode, error) {
+	key := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+	for m := ModeNormal; m < modeCount; m += 1 {
+		if strings.ReplaceAll(blendModeNames[m], "-", "") == key {
+			return m, nil
+		}
+	}
+	if m, ok := blendModeAliases[key]; ok {
+		return m, nil
+	}
+	return ModeNormal, fmt.Errorf("color: unknown blend mode %q", name)
+}
+
+func (m BlendMode) String() string {
+	if m >= modeCount {
+		return fmt.Sprintf("BlendMode(%d)", uint8(m))
+	}
+	return blendModeNames[m]
+}
+
+func (m BlendMode) Func() func(dst ColorFA, src ColorFA) ColorFA {
+	if m >= modeCount {
+		return ColorFA.BlendNormal
+	}
+	return blendModeFuncs[m]
+}
+
+func (m BlendMode) MarshalText() ([]byte, error) {
+	if m >= modeCount {
+		return nil, fmt.Errorf("color: invalid blend mode %d", uint8(m))
+	}
+	return []byte(blendModeNames[m]), nil
+}
+
+func (m *BlendMode) UnmarshalText(text []byte) error {
+	mode, err := ParseBlendMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+/******************
+	INTERNAL
+*******************/
+
+var blendModeNames = [modeCount]string{
+	ModeNormal:       "normal",
+	ModeMultiply:     "multiply",
+	ModeScreen:       "screen",
+	ModeOverlay:      "overlay",
+	ModeDarken:       "darken",
+	ModeLighten:      "lighten",
+	ModeColorDodge:   "color-dodge",
+	ModeColorBurn:    "color-burn",
+	ModeHardLight:    "hard-light",
+	ModeSoftLight:    "soft-light",
+	ModeDifference:   "difference",
+	ModeExclusion:    "exclusion",
+	ModeLinearBurn:   "linear-burn",
+	ModeLinearDodge:  "linear-dodge",
+	ModeLinearLight:  "linear-light",
+	ModeVividLight:   "vivid-light",
+	ModePinLight:     "pin-light",
+	ModeHardMix:      "hard-mix",
+	ModeSubtract:     "subtract",
+	ModeDivide:       "divide",
+	ModeDarkerColor:  "darker-color",
+	ModeLighterColor: "lighter-color",
+	ModeHue:          "hue",
+	ModeSaturation:   "saturation",
+	ModeColor:        "color",
+	ModeLuminosity:   "luminosity",
+
+	ModeClassicOverlay:    "classic-overlay",
+	ModeClassicHardLight:  "classic-hard-light",
+	ModeClassicSoftLight:  "classic-soft-light",
+	ModeClassicVividLight: "classic-vivid-light",
+}
+
+var blendModeAliases = map[string]BlendMode{
+	"dodge": ModeColorDodge,
+	"burn":  ModeColorBurn,
+	"add":   ModeLinearDodge,
+}
+
+var blendModeFuncs = [modeCount]func(ColorFA, ColorFA) ColorFA{
+	ModeNormal:       ColorFA.BlendNormal,
+	ModeMultiply:     ColorFA.BlendMultiply,
+	ModeScreen:       ColorFA.BlendScreen,
+	ModeOverlay:      ColorFA.BlendOverlay,
+	ModeDarken:       ColorFA.BlendDarken,
+	ModeLighten:      ColorFA.BlendLighten,
+	ModeColorDodge:   ColorFA.BlendColorDodge,
+	ModeColorBurn:    ColorFA.BlendColorBurn,
+	ModeHardLight:    ColorFA.BlendHardLight,
+	ModeSoftLight:    ColorFA.BlendSoftLight,
+	ModeDifference:   ColorFA.BlendDifference,
+	ModeExclusion:    ColorFA.BlendExclusion,
+	ModeLinearBurn:   ColorFA.BlendLinearBurn,
+	ModeLinearDodge:  ColorFA.BlendLinearDodge,
+	ModeLinearLight:  ColorFA.BlendLinearLight,
+	ModeVividLight:   ColorFA.BlendVividLight,
+	ModePinLight:     ColorFA.BlendPinLight,
+	ModeHardMix:      ColorFA.BlendHardMix,
+	ModeSubtract:     ColorFA.BlendSubtract,
+	ModeDivide:       ColorFA.BlendDivide,
+	ModeDarkerColor:  ColorFA.BlendDarkerColor,
+	ModeLighterColor: ColorFA.BlendLighterColor,
+	ModeHue:          ColorFA.BlendHue,
+	ModeSaturation:   ColorFA.BlendSaturation,
+	ModeColor:        ColorFA.BlendColor,
+	ModeLuminosity:   ColorFA.BlendLuminosity,
+
+	ModeClassicOverlay:    ColorFA.BlendClassicOverlay,
+	ModeClassicHardLight:  ColorFA.BlendClassicHardLight,
+	ModeClassicSoftLight:  ColorFA.BlendClassicSoftLight,
+	ModeClassicVividLight: ColorFA.BlendClassicVividLight,
+}
+
+func (c ColorFA) blendSeparable(src ColorFA, mix func(cb float32, cs float32) float32) ColorFA {
+	return c.blendNonSeparable(src, func(b ColorF, s ColorF) ColorF {
+		return ColorF{mix(b[0], s[0]), mix(b[1], s[1]), mix(b[2], s[2])}
+	})
+}
+
+func (c ColorFA) blendNonSeparable(src ColorFA, mix func(cb ColorF, cs ColorF) ColorF) ColorFA {
+	cb, cs := c.Clamp(), src.Clamp()
+	ab, as := cb[3], cs[3]
+	b := mix(cb.ToColorF(), cs.ToColorF())
+	var out PremulFA
+	for i := 0; i < 3; i += 1 {
+		mixed := ((1 - ab) * cs[i]) + (ab * math.Clamp(minF, b[i], maxF))
+		out[i] = (as * mixed) + ((1 - as) * ab * cb[i])
+	}
+	out[3] = as + (ab * (1 - as))
+	return out.Unpremultiply()
+}
+
+func normalB(cb float32, cs float32) float32 {
+	return cs
+}
+
+func multiplyB(cb float32, cs float32) float32 {
+	return cb * cs
+}
+
+func screenB(cb float32, cs float32) float32 {
+	return cb + cs - (cb * cs)
+}
+
+func overlayB(cb float32, cs float32) float32 {
+	return hardLightB(cs, cb)
+}
+
+func colorDodgeB(cb float32, cs float32) float32 {
+	if cb <= 0 {
+		return 0
+	}
+	if cs >= 1 {
+		return 1
+	}
+	return math.Min(1, cb/(1-cs))
+}
+
+func colorBurnB(cb float32, cs float32) float32 {
+	if cb >= 1 {
+		return 1
+	}
+	if cs <= 0 {
+		return 0
+	}
+	return 1 - math.Min(1, (1-cb)/cs)
+}
+
+func hardLightB(cb float32, cs float32) float32 {
+	if cs <= 0.5 {
+		return multiplyB(cb, 2*cs)
+	}
+	return screenB(cb, (2*cs)-1)
+}
+
+func softLightB(cb float32, cs float32) float32 {
+	if cs <= 0.5 {
+		return cb - ((1 - (2 * cs)) * cb * (1 - cb))
+	}
+	var d float32
+	if cb <= 0.25 {
+		d = ((((16 * cb) - 12) * cb) + 4) * cb
+	} else {
+		d = sqrt(cb)
+	}
+	return cb + (((2 * cs) - 1) * (d - cb))
+}
+
+func differenceB(cb float32, cs float32) float32 {
+	return math.Abs(cb - cs)
+}
+
+func exclusionB(cb float32, cs float32) float32 {
+	return cb + cs - (2 * cb * cs)
+}
+
+func linearBurnB(cb float32, cs float32) float32 {
+	return math.Max(0, cb+cs-1)
+}
+
+func linearDodgeB(cb float32, cs float32) float32 {
+	return math.Min(1, cb+cs)
+}
+
+func linearLightB(cb float32, cs float32) float32 {
+	return math.Clamp(0, cb+(2*cs)-1, 1)
+}
+
+func vividLightB(cb float32, cs float32) float32 {
+	if cs <= 0.5 {
+		return colorBurnB(cb, 2*cs)
+	}
+	return colorDodgeB(cb, (2*cs)-1)
+}
+
+func pinLightB(cb float32, cs float32) float32 {
+	if cs <= 0.5 {
+		return math.Min(cb, 2*cs)
+	}
+	return math.Max(cb, (2*cs)-1)
+}
+
+func hardMixB(cb float32, cs float32) float32 {
+	if cb+cs >= 1 {
+		return 1
+	}
+	return 0
+}
+
+func subtractB(cb float32, cs float32) float32 {
+	return math.Max(0, cb-cs)
+}
+
+func divideB(cb float32, cs float32) float32 {
+	if cs <= 0 {
+		if cb <= 0 {
+			return 0
+		}
+		return 1
+	}
+	return math.Min(1, cb/cs)
+}
+
+func hueB(cb ColorF, cs ColorF) ColorF {
+	return setLum(setSat(cs, satW3C(cb)), lumW3C(cb))
+}
+
+func saturationB(cb ColorF, cs ColorF) ColorF {
+	return setLum(setSat(cb, satW3C(cs)), lumW3C(cb))
+}
+
+func colorB(cb ColorF, cs ColorF) ColorF {
+	return setLum(cs, lumW3C(cb))
+}
+
+func luminosityB(cb ColorF, cs ColorF) ColorF {
+	return setLum(cb, lumW3C(cs))
+}
+
+func darkerColorB(cb ColorF, cs ColorF) ColorF {
+	if cs.ToColorFA().Luma() < cb.ToColorFA().Luma() {
+		return cs
+	}
+	return cb
+}
+
+func lighterColorB(cb ColorF, cs ColorF) ColorF {
+	if cs.ToColorFA().Luma() > cb.ToColorFA().Luma() {
+		return cs
+	}
+	return cb
+}
+
+// classicB adapts an alpha-ignoring color.go blend to blendNonSeparable.
+func classicB(blend func(ColorFA, ColorFA) ColorFA) func(cb ColorF, cs ColorF) ColorF {
+	return func(cb ColorF, cs ColorF) ColorF {
+		return blend(cb.ToColorFA(), cs.ToColorFA()).ToColorF()
+	}
+}
+
+// classicVividLightB is color.go's VividLight with the guarded burn and
+// dodge, so 0/0 channels resolve as in the W3C modes instead of to NaN.
+func classicVividLightB(cb ColorF, cs ColorF) ColorF {
+	mix := colorDodgeB
+	if cs.ToColorFA().Luma() < 0.5 {
+		mix = colorBurnB
+	}
+	return ColorF{mix(cb[0], cs[0]), mix(cb[1], cs[1]), mix(cb[2], cs[2])}
+}
+
+func lumW3C(c ColorF) float32 {
+	return (0.3 * c[0]) + (0.59 * c[1]) + (0.11 * c[2])
+}
+
+func satW3C(c ColorF) float32 {
+	return math.Max(c[0], math.Max(c[1], c[2])) - math.Min(c[0], math.Min(c[1], c[2]))
+}
+
+func clipColor(c ColorF) ColorF {
+	l := lumW3C(c)
+	n := math.Min(c[0], math.Min(c[1], c[2]))
+	x := math.Max(c[0], math.Max(c[1], c[2]))
+	if n < 0 {
+		for i := range c {
+			c[i] = l + (((c[i] - l) * l) / (l - n))
+		}
+	}
+	if x > 1 {
+		for i := range c {
+			c[i] = l + (((c[i] - l) * (1 - l)) / (x - l))
+		}
+	}
+	return c
+}
+
+func setLum(c ColorF, l float32) ColorF {
+	d := l - lumW3C(c)
+	return clipColor(ColorF{c[0] + d, c[1] + d, c[2] + d})
+}
+
+func setSat(c ColorF, s float32) ColorF {
+	max, mid, min := 0, 1, 2
+	if c[max] < c[mid] {
+		max, mid = mid, max
+	}
+	if c[mid] < c[min] {
+		mid, min = min, mid
+	}
+	if c[max] < c[mid] {
+		max, mid = mid, max
+	}
+	var out ColorF
+	if c[max] > c[min] {
+		out[mid] = ((c[mid] - c[min]) * s) / (c[max] - c[min])
+		out[max] = s
+	}
+	return out
+}
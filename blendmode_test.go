@@ -0,0 +1,20 @@
+package color
+
+import "testing"
+
+func TestCompositeNoNaN(t *testing.T) {
+	colors := []ColorFA{Black, White, {0, 0, 0, 0}, {1, 1, 1, 0.5}, {1, 0, 0, 1}, {0, 1, 1, 1}}
+	for m := ModeNormal; m < modeCount; m += 1 {
+		for _, dst := range colors {
+			for _, src := range colors {
+				got := Composite(dst, src, m, 1)
+				for _, v := range got {
+					if v != v {
+						t.Errorf("%s: Composite(%v, %v) = %v", m, dst, src, got)
+						break
+					}
+				}
+			}
+		}
+	}
+}
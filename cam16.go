@@ -0,0 +1,111 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// CAM16UCS holds CAM16-UCS J', a' and b' under sRGB viewing conditions
+// (D65 white, 64 lux ambient, 20% gray background, average surround)
+// followed by straight alpha.
+type CAM16UCS [4]float32
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToCAM16UCS() CAM16UCS {
+	xyz := c.ToXYZ(WhiteD65)
+	j, m, h := cam16SRGB.jmh(float64(xyz[0])*100, float64(xyz[1])*100, float64(xyz[2])*100)
+	jp := (1.7 * j) / (1 + (0.007 * j))
+	mp := math.Log(math.E, 1+(0.0228*m)) / 0.0228
+	return CAM16UCS{float32(jp), float32(mp * math.CosDeg(h)), float32(mp * math.SinDeg(h)), c[3]}
+}
+
+/******************
+	CAM16_UCS
+*******************/
+
+func (c CAM16UCS) J() float32 {
+	return c[0]
+}
+func (c CAM16UCS) A() float32 {
+	return c[1]
+}
+func (c CAM16UCS) B() float32 {
+	return c[2]
+}
+func (c CAM16UCS) Alpha() float32 {
+	return c[3]
+}
+
+/******************
+	INTERNAL
+*******************/
+
+type cam16Viewing struct {
+	dRGB [3]float64
+	fl   float64
+	n    float64
+	z    float64
+	nbb  float64
+	aw   float64
+	c    float64
+	nc   float64
+}
+
+var (
+	m16 = [3][3]float64{
+		{0.401288, 0.650173, -0.051461},
+		{-0.250268, 1.204414, 0.045854},
+		{-0.002079, 0.048952, 0.953127},
+	}
+	cam16SRGB = newCAM16Viewing(WhiteD65, 64/math.PI*0.2, 20)
+)
+
+func newCAM16Viewing(white WhitePoint, la float64, yb float64) cam16Viewing {
+	const f, c, nc = 1.0, 0.69, 1.0
+	xw, yw, zw := float64(white[0])*100, float64(white[1])*100, float64(white[2])*100
+	v := cam16Viewing{c: c, nc: nc}
+	k := 1 / ((5 * la) + 1)
+	k4 := k * k * k * k
+	v.fl = (0.2 * k4 * (5 * la)) + (0.1 * math.Square(1-k4) * cbrt(5*la))
+	v.n = yb / yw
+	v.z = 1.48 + sqrt(v.n)
+	v.nbb = 0.725 * math.Pow(1/v.n, 0.2)
+	d := math.Clamp(0, f*(1-((1/3.6)*math.Pow(math.E, (-la-42)/92))), 1)
+	rw, gw, bw := mul64(m16, xw, yw, zw)
+	v.dRGB = [3]float64{(d * yw / rw) + 1 - d, (d * yw / gw) + 1 - d, (d * yw / bw) + 1 - d}
+	ra, ga, ba := v.adapt(rw, gw, bw)
+	v.aw = ((2 * ra) + ga + (0.05 * ba) - 0.305) * v.nbb
+	return v
+}
+
+func (v cam16Viewing) adapt(r float64, g float64, b float64) (float64, float64, float64) {
+	return v.compress(r * v.dRGB[0]), v.compress(g * v.dRGB[1]), v.compress(b * v.dRGB[2])
+}
+
+func (v cam16Viewing) compress(x float64) float64 {
+	p := math.Pow(v.fl*math.Abs(x)/100, 0.42)
+	return (math.Sign(x) * 400 * p / (p + 27.13)) + 0.1
+}
+
+func (v cam16Viewing) jmh(x float64, y float64, z float64) (j float64, m float64, h float64) {
+	r, g, b := mul64(m16, x, y, z)
+	ra, ga, ba := v.adapt(r, g, b)
+	a := ra - (12 * ga / 11) + (ba / 11)
+	bb := (ra + ga - (2 * ba)) / 9
+	h = atan2Deg(bb, a)
+	et := 0.25 * (math.Cos((h*math.DEG_TO_RAD)+2) + 3.8)
+	achromatic := ((2 * ra) + ga + (0.05 * ba) - 0.305) * v.nbb
+	j = 100 * math.Pow(math.Max(0, achromatic/v.aw), v.c*v.z)
+	t := (50000.0 / 13.0 * v.nc * v.nbb * et * sqrt((a*a)+(bb*bb))) / (ra + ga + (21.0 / 20.0 * ba))
+	chroma := math.Pow(t, 0.9) * sqrt(j/100) * math.Pow(1.64-math.Pow(0.29, v.n), 0.73)
+	m = chroma * math.Pow(v.fl, 0.25)
+	return j, m, h
+}
+
+func mul64(m [3][3]float64, a float64, b float64, c float64) (float64, float64, float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
@@ -0,0 +1,134 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	StrategyNaive CMYKStrategy = iota
+	StrategyUCR
+	StrategyGCR
+)
+
+// CMYKStrategy selects how much of the gray component is moved to the black
+// plate: all of it (naive), only in near-neutral areas (under color
+// removal) or everywhere (gray component replacement).
+type CMYKStrategy uint8
+
+// CMYKSeparation configures ColorFA.ToCMYK. BlackStart is the gray level
+// (0-1) at which black generation begins, BlackAmount is the fraction of
+// the gray component replaced at full strength, and InkLimit is the maximum
+// total coverage (0-4, e.g. 3 for 300%); zero disables the limit.
+type CMYKSeparation struct {
+	Strategy    CMYKStrategy
+	BlackStart  float32
+	BlackAmount float32
+	InkLimit    float32
+}
+
+// CMYK holds cyan, magenta, yellow and black coverage followed by straight
+// alpha.
+type CMYK [5]float32
+
+var (
+	SeparationNaive = CMYKSeparation{Strategy: StrategyNaive}
+	SeparationUCR   = CMYKSeparation{Strategy: StrategyUCR, BlackStart: 0.2, BlackAmount: 1, InkLimit: 3}
+	SeparationGCR   = CMYKSeparation{Strategy: StrategyGCR, BlackStart: 0.1, BlackAmount: 0.7, InkLimit: 3}
+)
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToCMYK(sep CMYKSeparation) CMYK {
+	cc := c.Clamp()
+	cyan, magenta, yellow := maxF-cc[0], maxF-cc[1], maxF-cc[2]
+	gray := math.Min(cyan, math.Min(magenta, yellow))
+	var black float32
+	switch sep.Strategy {
+	case StrategyUCR:
+		neutral := 1 - (math.Max(cyan, math.Max(magenta, yellow)) - gray)
+		black = blackGeneration(gray, sep) * neutral
+	case StrategyGCR:
+		black = blackGeneration(gray, sep)
+	default:
+		black = gray
+	}
+	if black >= maxF {
+		return CMYK{0, 0, 0, maxF, cc[3]}.inkLimit(sep.InkLimit)
+	}
+	cyan = (cyan - black) / (1 - black)
+	magenta = (magenta - black) / (1 - black)
+	yellow = (yellow - black) / (1 - black)
+	return CMYK{cyan, magenta, yellow, black, cc[3]}.inkLimit(sep.InkLimit)
+}
+
+/******************
+	CMYK
+*******************/
+
+func (c CMYK) ToColorFA() ColorFA {
+	r := (1 - c[0]) * (1 - c[3])
+	g := (1 - c[1]) * (1 - c[3])
+	b := (1 - c[2]) * (1 - c[3])
+	return ColorFA{r, g, b, c[4]}.Clamp()
+}
+
+func (c CMYK) Cyan() float32 {
+	return c[0]
+}
+func (c CMYK) Magenta() float32 {
+	return c[1]
+}
+func (c CMYK) Yellow() float32 {
+	return c[2]
+}
+func (c CMYK) Black() float32 {
+	return c[3]
+}
+func (c CMYK) Alpha() float32 {
+	return c[4]
+}
+func (c CMYK) Coverage() float32 {
+	return c[0] + c[1] + c[2] + c[3]
+}
+
+func (c CMYK) SetCyan(cyan float32) CMYK {
+	return CMYK{cyan, c[1], c[2], c[3], c[4]}
+}
+func (c CMYK) SetMagenta(magenta float32) CMYK {
+	return CMYK{c[0], magenta, c[2], c[3], c[4]}
+}
+func (c CMYK) SetYellow(yellow float32) CMYK {
+	return CMYK{c[0], c[1], yellow, c[3], c[4]}
+}
+func (c CMYK) SetBlack(black float32) CMYK {
+	return CMYK{c[0], c[1], c[2], black, c[4]}
+}
+func (c CMYK) SetAlpha(alpha float32) CMYK {
+	return CMYK{c[0], c[1], c[2], c[3], alpha}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func blackGeneration(gray float32, sep CMYKSeparation) float32 {
+	start := math.Clamp(0, sep.BlackStart, 1)
+	if start >= 1 {
+		return 0
+	}
+	ramp := math.Clamp(0, (gray-start)/(1-start), 1)
+	return gray * ramp * math.Clamp(0, sep.BlackAmount, 1)
+}
+
+func (c CMYK) inkLimit(limit float32) CMYK {
+	if limit <= 0 || c.Coverage() <= limit {
+		return c
+	}
+	if c[3] >= limit {
+		return CMYK{0, 0, 0, limit, c[4]}
+	}
+	scale := (limit - c[3]) / (c[0] + c[1] + c[2])
+	return CMYK{c[0] * scale, c[1] * scale, c[2] * scale, c[3], c[4]}
+}
@@ -95,6 +95,28 @@ func NewColorHSVA(h float32, s float32, v float32, a float32) ColorFA {
 	return ColorFA{r + foundation, g + foundation, b + foundation, a}
 }
 
+func NewColorHSLA(h float32, s float32, l float32, a float32) ColorFA {
+	h = math.Clamp(0, h, 360)
+	s = math.Clamp(0, s, 1)
+	l = math.Clamp(0, l, 1)
+	a = math.Clamp(0, a, 1)
+	sv, v := hslToHsv(s, l)
+	return NewColorHSVA(h, sv, v, a)
+}
+
+func NewColorHWBA(h float32, w float32, b float32, a float32) ColorFA {
+	h = math.Clamp(0, h, 360)
+	w = math.Clamp(0, w, 1)
+	b = math.Clamp(0, b, 1)
+	a = math.Clamp(0, a, 1)
+	if w+b >= 1 {
+		gray := w / (w + b)
+		return ColorFA{gray, gray, gray, a}
+	}
+	v := 1 - b
+	return NewColorHSVA(h, 1-(w/v), v, a)
+}
+
 func NewColorRGBA(r float32, g float32, b float32, a float32) ColorFA {
 	return ColorFA{r, g, b, a}.Clamp()
 }
@@ -154,6 +176,17 @@ func (c ColorFA) HSVA() (h float32, s float32, v float32, a float32) {
 	return h, s, v, a
 }
 
+func (c ColorFA) HSLA() (h float32, s float32, l float32, a float32) {
+	h, sv, v, a := c.HSVA()
+	s, l = hsvToHsl(sv, v)
+	return h, s, l, a
+}
+
+func (c ColorFA) HWBA() (h float32, w float32, b float32, a float32) {
+	h, s, v, a := c.HSVA()
+	return h, (1 - s) * v, 1 - v, a
+}
+
 func (c ColorFA) Hex() string {
 	runes := make([]rune, 8)
 	c32 := c.ToColor32()
@@ -191,6 +224,22 @@ func (c ColorFA) Val() float32 {
 	_, _, v, _ := c.HSVA()
 	return v
 }
+func (c ColorFA) SatHSL() float32 {
+	_, s, _, _ := c.HSLA()
+	return s
+}
+func (c ColorFA) Lightness() float32 {
+	_, _, l, _ := c.HSLA()
+	return l
+}
+func (c ColorFA) Whiteness() float32 {
+	_, w, _, _ := c.HWBA()
+	return w
+}
+func (c ColorFA) Blackness() float32 {
+	_, _, b, _ := c.HWBA()
+	return b
+}
 
 func (c ColorFA) SetRed(red float32) ColorFA {
 	return ColorFA{red, c[1], c[2], c[3]}
@@ -231,6 +280,52 @@ func (c ColorFA) SetHueSat(hue float32, sat float32) ColorFA {
 func (c ColorFA) SetHueSatVal(hue float32, sat float32, val float32) ColorFA {
 	return NewColorHSVA(hue, sat, val, c[3])
 }
+func (c ColorFA) SetSatHSL(sat float32) ColorFA {
+	h, _, l, a := c.HSLA()
+	return NewColorHSLA(h, sat, l, a)
+}
+func (c ColorFA) SetLightness(light float32) ColorFA {
+	h, s, _, a := c.HSLA()
+	return NewColorHSLA(h, s, light, a)
+}
+func (c ColorFA) SetSatLightness(sat float32, light float32) ColorFA {
+	h, _, _, a := c.HSLA()
+	return NewColorHSLA(h, sat, light, a)
+}
+func (c ColorFA) SetHueLightness(hue float32, light float32) ColorFA {
+	_, s, _, a := c.HSLA()
+	return NewColorHSLA(hue, s, light, a)
+}
+func (c ColorFA) SetHueSatHSL(hue float32, sat float32) ColorFA {
+	_, _, l, a := c.HSLA()
+	return NewColorHSLA(hue, sat, l, a)
+}
+func (c ColorFA) SetHueSatLightness(hue float32, sat float32, light float32) ColorFA {
+	return NewColorHSLA(hue, sat, light, c[3])
+}
+func (c ColorFA) SetWhiteness(white float32) ColorFA {
+	h, _, b, a := c.HWBA()
+	return NewColorHWBA(h, white, b, a)
+}
+func (c ColorFA) SetBlackness(black float32) ColorFA {
+	h, w, _, a := c.HWBA()
+	return NewColorHWBA(h, w, black, a)
+}
+func (c ColorFA) SetWhiteBlack(white float32, black float32) ColorFA {
+	h, _, _, a := c.HWBA()
+	return NewColorHWBA(h, white, black, a)
+}
+func (c ColorFA) SetHueWhiteness(hue float32, white float32) ColorFA {
+	_, _, b, a := c.HWBA()
+	return NewColorHWBA(hue, white, b, a)
+}
+func (c ColorFA) SetHueBlackness(hue float32, black float32) ColorFA {
+	_, w, _, a := c.HWBA()
+	return NewColorHWBA(hue, w, black, a)
+}
+func (c ColorFA) SetHueWhiteBlack(hue float32, white float32, black float32) ColorFA {
+	return NewColorHWBA(hue, white, black, c[3])
+}
 func (c ColorFA) Luma() float32 {
 	return (c[0] * lumaR) + (c[1] * lumaG) + (c[2] * lumaB)
 }
@@ -651,6 +746,57 @@ func lerp(a float32, b float32, ratio float32) float32 {
 	return a + (diff * ratio)
 }
 
+func sqrt[T math.Float](v T) T {
+	return math.Pow(v, 0.5)
+}
+
+func cbrt[T math.Float](v T) T {
+	return math.Sign(v) * math.Pow(math.Abs(v), 1.0/3.0)
+}
+
+func atan2Deg[T math.Float](y T, x T) T {
+	if x == 0 {
+		if y > 0 {
+			return 90
+		}
+		if y < 0 {
+			return 270
+		}
+		return 0
+	}
+	deg := math.ATanDeg(y / x)
+	if x < 0 {
+		deg += 180
+	} else if y < 0 {
+		deg += 360
+	}
+	return math.FMod(deg, 360)
+}
+
+func wrapHue(h float32) float32 {
+	h = math.FMod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func hsvToHsl(sv float32, v float32) (s float32, l float32) {
+	l = v * (1 - (sv / 2))
+	if l <= 0 || l >= 1 {
+		return 0, l
+	}
+	return (v - l) / math.Min(l, 1-l), l
+}
+
+func hslToHsv(s float32, l float32) (sv float32, v float32) {
+	v = l + (s * math.Min(l, 1-l))
+	if v <= 0 {
+		return 0, v
+	}
+	return 2 * (1 - (l / v)), v
+}
+
 func overlow(a float32, b float32) float32 {
 	return 2 * a * b
 }
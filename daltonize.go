@@ -0,0 +1,57 @@
+package color
+
+/******************
+	COLOR_FA
+*******************/
+
+// Daltonize moves the color difference that a viewer with the deficiency
+// cannot see into channels they can (Fidaner et al.), scaled by strength
+// (0-1). Achromatopsia has no remaining color channel, so it is left alone.
+func (c ColorFA) Daltonize(d Deficiency, strength float32) ColorFA {
+	var shift matrix3
+	switch d {
+	case Protanopia, Deuteranopia, Protanomaly, Deuteranomaly:
+		shift = daltonizeRedGreen
+	case Tritanopia, Tritanomaly:
+		shift = daltonizeBlueYellow
+	default:
+		return c
+	}
+	lin := c.ToLinear()
+	sim := lin.SimulateCVD(d, 1)
+	r, g, b := shift.mul(lin[0]-sim[0], lin[1]-sim[1], lin[2]-sim[2])
+	return LinearColorFA{lin[0] + (r * strength), lin[1] + (g * strength), lin[2] + (b * strength), c[3]}.ToColorFA()
+}
+
+func DaltonizeSlice(pix []ColorFA, d Deficiency, strength float32) {
+	for i, c := range pix {
+		pix[i] = c.Daltonize(d, strength)
+	}
+}
+
+/******************
+	IMAGE_FA
+*******************/
+
+func (p *ImageFA) Daltonize(d Deficiency, strength float32) {
+	p.Map(func(c ColorFA) ColorFA {
+		return c.Daltonize(d, strength)
+	})
+}
+
+/******************
+	INTERNAL
+*******************/
+
+var (
+	daltonizeRedGreen = matrix3{
+		{0, 0, 0},
+		{0.7, 1, 0},
+		{0.7, 0, 1},
+	}
+	daltonizeBlueYellow = matrix3{
+		{1, 0, 0.7},
+		{0, 1, 0.7},
+		{0, 0, 0},
+	}
+)
@@ -0,0 +1,140 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// DeltaE94Weights holds the application-dependent CIE94 parameters.
+type DeltaE94Weights struct {
+	KL float32
+	K1 float32
+	K2 float32
+}
+
+var (
+	DeltaE94GraphicArts = DeltaE94Weights{KL: 1, K1: 0.045, K2: 0.015}
+	DeltaE94Textiles    = DeltaE94Weights{KL: 2, K1: 0.048, K2: 0.014}
+)
+
+// The ColorFA Delta E methods compare colors in CIELAB under D65. DeltaE94
+// and DeltaECMC are not symmetric; the receiver is the reference color.
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) DeltaE76(other ColorFA) float32 {
+	return c.ToLab(WhiteD65).DeltaE76(other.ToLab(WhiteD65))
+}
+func (c ColorFA) DeltaE94(other ColorFA, weights DeltaE94Weights) float32 {
+	return c.ToLab(WhiteD65).DeltaE94(other.ToLab(WhiteD65), weights)
+}
+func (c ColorFA) DeltaE2000(other ColorFA, kL float32, kC float32, kH float32) float32 {
+	return c.ToLab(WhiteD65).DeltaE2000(other.ToLab(WhiteD65), kL, kC, kH)
+}
+func (c ColorFA) DeltaECMC(other ColorFA, l float32, cc float32) float32 {
+	return c.ToLab(WhiteD65).DeltaECMC(other.ToLab(WhiteD65), l, cc)
+}
+
+/******************
+	LAB
+*******************/
+
+func (c Lab) DeltaE76(other Lab) float32 {
+	dL, da, db := c[0]-other[0], c[1]-other[1], c[2]-other[2]
+	return sqrt((dL * dL) + (da * da) + (db * db))
+}
+
+func (c Lab) DeltaE94(other Lab, weights DeltaE94Weights) float32 {
+	c1 := sqrt((c[1] * c[1]) + (c[2] * c[2]))
+	c2 := sqrt((other[1] * other[1]) + (other[2] * other[2]))
+	dL, da, db, dC := c[0]-other[0], c[1]-other[1], c[2]-other[2], c1-c2
+	dH2 := math.Max(0, (da*da)+(db*db)-(dC*dC))
+	sC := 1 + (weights.K1 * c1)
+	sH := 1 + (weights.K2 * c1)
+	tL := dL / weights.KL
+	tC := dC / sC
+	return sqrt((tL * tL) + (tC * tC) + (dH2 / (sH * sH)))
+}
+
+func (c Lab) DeltaE2000(other Lab, kL float32, kC float32, kH float32) float32 {
+	l1, a1, b1 := float64(c[0]), float64(c[1]), float64(c[2])
+	l2, a2, b2 := float64(other[0]), float64(other[1]), float64(other[2])
+	cBar := (sqrt((a1*a1)+(b1*b1)) + sqrt((a2*a2)+(b2*b2))) / 2
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - sqrt(cBar7/(cBar7+pow25To7)))
+	a1p, a2p := (1+g)*a1, (1+g)*a2
+	c1p, c2p := sqrt((a1p*a1p)+(b1*b1)), sqrt((a2p*a2p)+(b2*b2))
+	h1p, h2p := atan2Deg(b1, a1p), atan2Deg(b2, a2p)
+	dLp := l2 - l1
+	dCp := c2p - c1p
+	var dhp, hBarP float64
+	if c1p*c2p == 0 {
+		dhp = 0
+		hBarP = h1p + h2p
+	} else {
+		dhp = h2p - h1p
+		if dhp > 180 {
+			dhp -= 360
+		} else if dhp < -180 {
+			dhp += 360
+		}
+		hBarP = (h1p + h2p) / 2
+		if math.Abs(h1p-h2p) > 180 {
+			if h1p+h2p < 360 {
+				hBarP += 180
+			} else {
+				hBarP -= 180
+			}
+		}
+	}
+	dHp := 2 * sqrt(c1p*c2p) * math.SinDeg(dhp/2)
+	lBarP := (l1 + l2) / 2
+	cBarP := (c1p + c2p) / 2
+	t := 1 - (0.17 * math.CosDeg(hBarP-30)) + (0.24 * math.CosDeg(2*hBarP)) + (0.32 * math.CosDeg((3*hBarP)+6)) - (0.20 * math.CosDeg((4*hBarP)-63))
+	dTheta := 30 * math.Pow(math.E, -math.Square((hBarP-275)/25))
+	cBarP7 := math.Pow(cBarP, 7)
+	rC := 2 * sqrt(cBarP7/(cBarP7+pow25To7))
+	lBar50 := math.Square(lBarP - 50)
+	sL := 1 + ((0.015 * lBar50) / sqrt(20+lBar50))
+	sC := 1 + (0.045 * cBarP)
+	sH := 1 + (0.015 * cBarP * t)
+	rT := -math.SinDeg(2*dTheta) * rC
+	tL := dLp / (float64(kL) * sL)
+	tC := dCp / (float64(kC) * sC)
+	tH := dHp / (float64(kH) * sH)
+	return float32(sqrt((tL * tL) + (tC * tC) + (tH * tH) + (rT * tC * tH)))
+}
+
+func (c Lab) DeltaECMC(other Lab, l float32, cc float32) float32 {
+	c1 := sqrt((c[1] * c[1]) + (c[2] * c[2]))
+	c2 := sqrt((other[1] * other[1]) + (other[2] * other[2]))
+	dL, da, db, dC := c[0]-other[0], c[1]-other[1], c[2]-other[2], c1-c2
+	dH2 := math.Max(0, (da*da)+(db*db)-(dC*dC))
+	var sL float32
+	if c[0] < 16 {
+		sL = 0.511
+	} else {
+		sL = (0.040975 * c[0]) / (1 + (0.01765 * c[0]))
+	}
+	sC := ((0.0638 * c1) / (1 + (0.0131 * c1))) + 0.638
+	h1 := atan2Deg(c[2], c[1])
+	var t float32
+	if h1 >= 164 && h1 <= 345 {
+		t = 0.56 + math.Abs(0.2*math.CosDeg(h1+168))
+	} else {
+		t = 0.36 + math.Abs(0.4*math.CosDeg(h1+35))
+	}
+	c14 := c1 * c1 * c1 * c1
+	f := sqrt(c14 / (c14 + 1900))
+	sH := sC * ((f * t) + 1 - f)
+	tL := dL / (l * sL)
+	tC := dC / (cc * sC)
+	return sqrt((tL * tL) + (tC * tC) + (dH2 / (sH * sH)))
+}
+
+/******************
+	INTERNAL
+*******************/
+
+const pow25To7 = 6103515625
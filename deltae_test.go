@@ -0,0 +1,115 @@
+package color
+
+import "testing"
+
+type deltaEPair struct {
+	a    Lab
+	b    Lab
+	want float32
+}
+
+// sharmaPairs is the CIEDE2000 test data from Sharma, Wu and Dalal (2005).
+var sharmaPairs = []deltaEPair{
+	{Lab{50.0000, 2.6772, -79.7751, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 2.0425},
+	{Lab{50.0000, 3.1571, -77.2803, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 2.8615},
+	{Lab{50.0000, 2.8361, -74.0200, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 3.4412},
+	{Lab{50.0000, -1.3802, -84.2814, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 1.0000},
+	{Lab{50.0000, -1.1848, -84.8006, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 1.0000},
+	{Lab{50.0000, -0.9009, -85.5211, 1}, Lab{50.0000, 0.0000, -82.7485, 1}, 1.0000},
+	{Lab{50.0000, 0.0000, 0.0000, 1}, Lab{50.0000, -1.0000, 2.0000, 1}, 2.3669},
+	{Lab{50.0000, -1.0000, 2.0000, 1}, Lab{50.0000, 0.0000, 0.0000, 1}, 2.3669},
+	{Lab{50.0000, 2.4900, -0.0010, 1}, Lab{50.0000, -2.4900, 0.0009, 1}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010, 1}, Lab{50.0000, -2.4900, 0.0010, 1}, 7.1792},
+	{Lab{50.0000, 2.4900, -0.0010, 1}, Lab{50.0000, -2.4900, 0.0011, 1}, 7.2195},
+	{Lab{50.0000, 2.4900, -0.0010, 1}, Lab{50.0000, -2.4900, 0.0012, 1}, 7.2195},
+	{Lab{50.0000, -0.0010, 2.4900, 1}, Lab{50.0000, 0.0009, -2.4900, 1}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900, 1}, Lab{50.0000, 0.0010, -2.4900, 1}, 4.8045},
+	{Lab{50.0000, -0.0010, 2.4900, 1}, Lab{50.0000, 0.0011, -2.4900, 1}, 4.7461},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{50.0000, 0.0000, -2.5000, 1}, 4.3065},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{73.0000, 25.0000, -18.0000, 1}, 27.1492},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{61.0000, -5.0000, 29.0000, 1}, 22.8977},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{56.0000, -27.0000, -3.0000, 1}, 31.9030},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{58.0000, 24.0000, 15.0000, 1}, 19.4535},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{50.0000, 3.1736, 0.5854, 1}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{50.0000, 3.2972, 0.0000, 1}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{50.0000, 1.8634, 0.5757, 1}, 1.0000},
+	{Lab{50.0000, 2.5000, 0.0000, 1}, Lab{50.0000, 3.2592, 0.3350, 1}, 1.0000},
+	{Lab{60.2574, -34.0099, 36.2677, 1}, Lab{60.4626, -34.1751, 39.4387, 1}, 1.2644},
+	{Lab{63.0109, -31.0961, -5.8663, 1}, Lab{62.8187, -29.7946, -4.0864, 1}, 1.2630},
+	{Lab{61.2901, 3.7196, -5.3901, 1}, Lab{61.4292, 2.2480, -4.9620, 1}, 1.8731},
+	{Lab{35.0831, -44.1164, 3.7933, 1}, Lab{35.0232, -40.0716, 1.5901, 1}, 1.8645},
+	{Lab{22.7233, 20.0904, -46.6940, 1}, Lab{23.0331, 14.9730, -42.5619, 1}, 2.0373},
+	{Lab{36.4612, 47.8580, 18.3852, 1}, Lab{36.2715, 50.5065, 21.2231, 1}, 1.4146},
+	{Lab{90.8027, -2.0831, 1.4410, 1}, Lab{91.1528, -1.6435, 0.0447, 1}, 1.4441},
+	{Lab{90.9257, -0.5406, -0.9208, 1}, Lab{88.6381, -0.8985, -0.7239, 1}, 1.5381},
+	{Lab{6.7747, -0.2908, -2.4247, 1}, Lab{5.8714, -0.0985, -2.2286, 1}, 0.6377},
+	{Lab{2.0776, 0.0795, -1.1350, 1}, Lab{0.9033, -0.0636, -0.5514, 1}, 0.9082},
+}
+
+func TestDeltaE2000Sharma(t *testing.T) {
+	for i, p := range sharmaPairs {
+		if got := p.a.DeltaE2000(p.b, 1, 1, 1); !deltaEClose(got, p.want) {
+			t.Errorf("pair %d: DeltaE2000 = %.4f, want %.4f", i+1, got, p.want)
+		}
+		if got := p.b.DeltaE2000(p.a, 1, 1, 1); !deltaEClose(got, p.want) {
+			t.Errorf("pair %d reversed: DeltaE2000 = %.4f, want %.4f", i+1, got, p.want)
+		}
+	}
+}
+
+func TestDeltaE94(t *testing.T) {
+	tests := []struct {
+		a        Lab
+		b        Lab
+		graphics float32
+		textiles float32
+	}{
+		{Lab{50, 2.5, 0, 1}, Lab{73, 25, -18, 1}, 34.6892, 28.2503},
+		{Lab{50, 2.5, 0, 1}, Lab{61, -5, 29, 1}, 29.4414, 27.7308},
+		{Lab{60.2574, -34.0099, 36.2677, 1}, Lab{60.4626, -34.1751, 39.4387, 1}, 1.3910, 1.3897},
+		{Lab{22.7233, 20.0904, -46.6940, 1}, Lab{23.0331, 14.9730, -42.5619, 1}, 2.5561, 2.5310},
+		{Lab{6.7747, -0.2908, -2.4247, 1}, Lab{5.8714, -0.0985, -2.2286, 1}, 0.9385, 0.5182},
+	}
+	for i, tt := range tests {
+		if got := tt.a.DeltaE94(tt.b, DeltaE94GraphicArts); !deltaEClose(got, tt.graphics) {
+			t.Errorf("case %d: graphic arts DeltaE94 = %.4f, want %.4f", i, got, tt.graphics)
+		}
+		if got := tt.a.DeltaE94(tt.b, DeltaE94Textiles); !deltaEClose(got, tt.textiles) {
+			t.Errorf("case %d: textiles DeltaE94 = %.4f, want %.4f", i, got, tt.textiles)
+		}
+	}
+}
+
+func TestDeltaECMC(t *testing.T) {
+	tests := []struct {
+		a           Lab
+		b           Lab
+		acceptance  float32
+		perceptible float32
+	}{
+		{Lab{50, 2.5, 0, 1}, Lab{73, 25, -18, 1}, 37.9233, 42.1088},
+		{Lab{50, 2.5, 0, 1}, Lab{56, -27, -3, 1}, 38.0618, 38.3601},
+		{Lab{60.2574, -34.0099, 36.2677, 1}, Lab{60.4626, -34.1751, 39.4387, 1}, 1.4205, 1.4282},
+		{Lab{36.4612, 47.8580, 18.3852, 1}, Lab{36.2715, 50.5065, 21.2231, 1}, 1.7396, 1.7489},
+		{Lab{6.7747, -0.2908, -2.4247, 1}, Lab{5.8714, -0.0985, -2.2286, 1}, 0.9528, 1.8032},
+	}
+	for i, tt := range tests {
+		if got := tt.a.DeltaECMC(tt.b, 2, 1); !deltaEClose(got, tt.acceptance) {
+			t.Errorf("case %d: CMC 2:1 = %.4f, want %.4f", i, got, tt.acceptance)
+		}
+		if got := tt.a.DeltaECMC(tt.b, 1, 1); !deltaEClose(got, tt.perceptible) {
+			t.Errorf("case %d: CMC 1:1 = %.4f, want %.4f", i, got, tt.perceptible)
+		}
+	}
+}
+
+// deltaEClose allows for the four-decimal rounding of the reference values
+// and float32 precision on larger differences.
+func deltaEClose(got float32, want float32) bool {
+	tol := float32(1e-4)
+	if want > 10 {
+		tol = want * 1e-5
+	}
+	diff := got - want
+	return diff <= tol && diff >= -tol
+}
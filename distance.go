@@ -0,0 +1,56 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// DistanceFunc measures how different two colors look, larger meaning more
+// different. Any of the Delta E methods can be used as one.
+type DistanceFunc func(a ColorFA, b ColorFA) float32
+
+var (
+	DistanceEOK      DistanceFunc = ColorFA.DeltaEOK
+	DistanceCAM16UCS DistanceFunc = ColorFA.DeltaECAM16UCS
+	Distance76       DistanceFunc = ColorFA.DeltaE76
+	Distance2000     DistanceFunc = func(a ColorFA, b ColorFA) float32 {
+		return a.DeltaE2000(b, 1, 1, 1)
+	}
+)
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) DeltaEOK(other ColorFA) float32 {
+	a, b := c.ToOklab(), other.ToOklab()
+	return euclidean3(a[0]-b[0], a[1]-b[1], a[2]-b[2])
+}
+
+func (c ColorFA) DeltaECAM16UCS(other ColorFA) float32 {
+	a, b := c.ToCAM16UCS(), other.ToCAM16UCS()
+	return euclidean3(a[0]-b[0], a[1]-b[1], a[2]-b[2])
+}
+
+/******************
+	DISTANCE_FUNC
+*******************/
+
+// Nearest returns the index of the palette entry closest to target, or -1
+// if the palette is empty.
+func (d DistanceFunc) Nearest(target ColorFA, palette []ColorFA) (index int, distance float32) {
+	index, distance = -1, math.MAX_F32
+	for i, p := range palette {
+		if dist := d(target, p); dist < distance {
+			index, distance = i, dist
+		}
+	}
+	return index, distance
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func euclidean3(a float32, b float32, c float32) float32 {
+	return sqrt((a * a) + (b * b) + (c * c))
+}
@@ -0,0 +1,290 @@
+package color
+
+import (
+	"fmt"
+	"sort"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+// Easing reshapes the 0-1 progress through a gradient segment.
+type Easing func(t float32) float32
+
+var (
+	EaseLinear    Easing = func(t float32) float32 { return t }
+	EaseIn        Easing = func(t float32) float32 { return t * t }
+	EaseOut       Easing = func(t float32) float32 { return t * (2 - t) }
+	EaseInOut     Easing = func(t float32) float32 { return t * t * (3 - (2 * t)) }
+	EaseInCubic   Easing = func(t float32) float32 { return t * t * t }
+	EaseOutCubic  Easing = func(t float32) float32 { return 1 - math.Cube(1-t) }
+	EaseInOutSine Easing = func(t float32) float32 { return (1 - math.CosDeg(t*180)) / 2 }
+)
+
+type InterpolationSpace uint8
+
+const (
+	SpaceSRGB InterpolationSpace = iota
+	SpaceLinearSRGB
+	SpaceHSV
+	SpaceHSL
+	SpaceLab
+	SpaceLCh
+	SpaceOklab
+	SpaceOklch
+	spaceCount
+)
+
+// HueInterpolation picks which way around the hue circle polar spaces
+// travel, as in CSS Color 4.
+type HueInterpolation uint8
+
+const (
+	HueShorter HueInterpolation = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+	hueInterpolationCount
+)
+
+// GradientStop places Color at Position. Easing and Hint shape the segment
+// from this stop to the next: Easing nil is linear, and Hint is the CSS
+// color hint given as a fraction of the segment (unset outside 0-1).
+type GradientStop struct {
+	Position float32
+	Color    ColorFA
+	Easing   Easing
+	Hint     float32
+}
+
+// Gradient interpolates its stops in Space with premultiplied alpha. Lab and
+// LCh use a D50 white, as CSS does, and gray stops take the hue of their
+// neighbor.
+type Gradient struct {
+	Stops []GradientStop
+	Space InterpolationSpace
+	Hue   HueInterpolation
+}
+
+/******************
+	GRADIENT
+*******************/
+
+func NewGradient(space InterpolationSpace, stops ...GradientStop) Gradient {
+	sorted := append([]GradientStop(nil), stops...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+	return Gradient{Stops: sorted, Space: space}
+}
+
+// NewGradientEven spaces colors evenly from 0 to 1.
+func NewGradientEven(space InterpolationSpace, colors ...ColorFA) Gradient {
+	stops := make([]GradientStop, len(colors))
+	for i, c := range colors {
+		stops[i] = GradientStop{Color: c}
+		if len(colors) > 1 {
+			stops[i].Position = float32(i) / float32(len(colors)-1)
+		}
+	}
+	return Gradient{Stops: stops, Space: space}
+}
+
+func (g Gradient) SetSpace(space InterpolationSpace) Gradient {
+	g.Space = space
+	return g
+}
+func (g Gradient) SetHue(hue HueInterpolation) Gradient {
+	g.Hue = hue
+	return g
+}
+
+// At samples the gradient at t, holding the end colors outside the first
+// and last stops. Stops are assumed sorted by Position. A NaN t gives the
+// first stop's color.
+func (g Gradient) At(t float32) ColorFA {
+	n := len(g.Stops)
+	if n == 0 {
+		return ColorFA{0, 0, 0, 0}
+	}
+	if t != t || t <= g.Stops[0].Position {
+		return g.Stops[0].Color
+	}
+	if t >= g.Stops[n-1].Position {
+		return g.Stops[n-1].Color
+	}
+	next := sort.Search(n, func(i int) bool {
+		return g.Stops[i].Position > t
+	})
+	a, b := g.Stops[next-1], g.Stops[next]
+	local := (t - a.Position) / (b.Position - a.Position)
+	if a.Hint > 0 && a.Hint < 1 {
+		local = math.Pow(local, math.Log(a.Hint, 0.5))
+	}
+	if a.Easing != nil {
+		local = a.Easing(local)
+	}
+	return g.Space.Interpolate(a.Color, b.Color, local, g.Hue)
+}
+
+/******************
+	INTERPOLATION_SPACE
+*******************/
+
+// Interpolate mixes a toward b by ratio in this space.
+func (s InterpolationSpace) Interpolate(a ColorFA, b ColorFA, ratio float32, hue HueInterpolation) ColorFA {
+	if s >= spaceCount {
+		s = SpaceSRGB
+	}
+	ca, cb := s.toCoords(a), s.toCoords(b)
+	hi := spaceHueIndex[s]
+	if hi >= 0 {
+		if achromatic(a) {
+			ca[hi] = cb[hi]
+		} else if achromatic(b) {
+			cb[hi] = ca[hi]
+		}
+		ca[hi], cb[hi] = hue.fixup(ca[hi], cb[hi])
+	}
+	var out [4]float32
+	out[3] = lerp(ca[3], cb[3], ratio)
+	for i := 0; i < 3; i += 1 {
+		if i == hi {
+			out[i] = wrapHue(lerp(ca[i], cb[i], ratio))
+			continue
+		}
+		out[i] = lerp(ca[i]*ca[3], cb[i]*cb[3], ratio)
+		if out[3] > 0 {
+			out[i] /= out[3]
+		}
+	}
+	return s.fromCoords(out)
+}
+
+func (s InterpolationSpace) String() string {
+	if s >= spaceCount {
+		return fmt.Sprintf("InterpolationSpace(%d)", uint8(s))
+	}
+	return spaceNames[s]
+}
+
+/******************
+	HUE_INTERPOLATION
+*******************/
+
+func (h HueInterpolation) String() string {
+	if h >= hueInterpolationCount {
+		return fmt.Sprintf("HueInterpolation(%d)", uint8(h))
+	}
+	return hueInterpolationNames[h]
+}
+
+/******************
+	INTERNAL
+*******************/
+
+var spaceNames = [spaceCount]string{
+	SpaceSRGB:       "srgb",
+	SpaceLinearSRGB: "srgb-linear",
+	SpaceHSV:        "hsv",
+	SpaceHSL:        "hsl",
+	SpaceLab:        "lab",
+	SpaceLCh:        "lch",
+	SpaceOklab:      "oklab",
+	SpaceOklch:      "oklch",
+}
+
+var hueInterpolationNames = [hueInterpolationCount]string{
+	HueShorter:    "shorter",
+	HueLonger:     "longer",
+	HueIncreasing: "increasing",
+	HueDecreasing: "decreasing",
+}
+
+var spaceHueIndex = [spaceCount]int{
+	SpaceSRGB:       -1,
+	SpaceLinearSRGB: -1,
+	SpaceHSV:        0,
+	SpaceHSL:        0,
+	SpaceLab:        -1,
+	SpaceLCh:        2,
+	SpaceOklab:      -1,
+	SpaceOklch:      2,
+}
+
+func (s InterpolationSpace) toCoords(c ColorFA) [4]float32 {
+	switch s {
+	case SpaceLinearSRGB:
+		return c.ToLinear()
+	case SpaceHSV:
+		h, sv, v, a := c.HSVA()
+		return [4]float32{h, sv, v, a}
+	case SpaceHSL:
+		h, sl, l, a := c.HSLA()
+		return [4]float32{h, sl, l, a}
+	case SpaceLab:
+		return c.ToLab(WhiteD50)
+	case SpaceLCh:
+		return c.ToLCh(WhiteD50)
+	case SpaceOklab:
+		return c.ToOklab()
+	case SpaceOklch:
+		return c.ToOklch()
+	}
+	return c
+}
+
+func (s InterpolationSpace) fromCoords(c [4]float32) ColorFA {
+	switch s {
+	case SpaceLinearSRGB:
+		return LinearColorFA(c).ToColorFA()
+	case SpaceHSV:
+		return NewColorHSVA(c[0], c[1], c[2], c[3])
+	case SpaceHSL:
+		return NewColorHSLA(c[0], c[1], c[2], c[3])
+	case SpaceLab:
+		return Lab(c).ToColorFA(WhiteD50)
+	case SpaceLCh:
+		return LCh(c).ToColorFA(WhiteD50)
+	case SpaceOklab:
+		return Oklab(c).ToColorFA()
+	case SpaceOklch:
+		return Oklch(c).MapToGamut().ToColorFA()
+	}
+	return ColorFA(c).Clamp()
+}
+
+// fixup adjusts two hues so a plain lerp between them travels the chosen
+// way around the circle.
+func (h HueInterpolation) fixup(a float32, b float32) (float32, float32) {
+	a, b = wrapHue(a), wrapHue(b)
+	diff := b - a
+	switch h {
+	case HueLonger:
+		if diff > 0 && diff < 180 {
+			a += 360
+		} else if diff > -180 && diff <= 0 {
+			b += 360
+		}
+	case HueIncreasing:
+		if b < a {
+			b += 360
+		}
+	case HueDecreasing:
+		if a < b {
+			a += 360
+		}
+	default:
+		if diff > 180 {
+			a += 360
+		} else if diff < -180 {
+			b += 360
+		}
+	}
+	return a, b
+}
+
+func achromatic(c ColorFA) bool {
+	max := math.Max(c[0], math.Max(c[1], c[2]))
+	min := math.Min(c[0], math.Min(c[1], c[2]))
+	return max-min <= epsilon
+}
@@ -0,0 +1,237 @@
+package color
+
+import (
+	"fmt"
+	"image/draw"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	defaultLUTSize = 1024
+)
+
+type GradientShape uint8
+
+const (
+	ShapeLinear GradientShape = iota
+	ShapeRadial
+	ShapeConic
+	ShapeDiamond
+	shapeCount
+)
+
+// SpreadMode decides what a gradient shows outside 0-1.
+type SpreadMode uint8
+
+const (
+	SpreadPad SpreadMode = iota
+	SpreadRepeat
+	SpreadReflect
+	spreadCount
+)
+
+// GradientRenderer rasterizes a Gradient. Linear runs from (X0, Y0) to
+// (X1, Y1). Radial, Conic and Diamond are centered on (X0, Y0) with
+// (X1, Y1) on the t = 1 edge, or at the start angle for Conic. Pixels are
+// sampled at their centers from a LUTSize-entry table (1024 if unset).
+// Dither adds a 4x4 Bayer pattern to red, green and blue before quantizing
+// to 8 bits, so it applies to Render32 and Render but not RenderFA. Alpha is
+// rounded without dithering.
+type GradientRenderer struct {
+	Gradient Gradient
+	Shape    GradientShape
+	Spread   SpreadMode
+	X0       float32
+	Y0       float32
+	X1       float32
+	Y1       float32
+	Dither   bool
+	LUTSize  int
+}
+
+/******************
+	GRADIENT
+*******************/
+
+// LUT samples n evenly spaced colors from position 0 to 1.
+func (g Gradient) LUT(n int) []ColorFA {
+	if n <= 0 {
+		return nil
+	}
+	lut := make([]ColorFA, n)
+	if n == 1 {
+		lut[0] = g.At(0)
+		return lut
+	}
+	for i := range lut {
+		lut[i] = g.At(float32(i) / float32(n-1))
+	}
+	return lut
+}
+
+func (g Gradient) LUT32(n int) []Color32 {
+	return toColor32Slice(g.LUT(n))
+}
+
+/******************
+	GRADIENT_RENDERER
+*******************/
+
+// T returns the gradient position at (x, y) with the spread mode applied.
+func (r GradientRenderer) T(x float32, y float32) float32 {
+	dx, dy := r.X1-r.X0, r.Y1-r.Y0
+	px, py := x-r.X0, y-r.Y0
+	var t float32
+	switch r.Shape {
+	case ShapeRadial:
+		if radius := sqrt((dx * dx) + (dy * dy)); radius > 0 {
+			t = sqrt((px*px)+(py*py)) / radius
+		}
+	case ShapeConic:
+		t = wrapHue(atan2Deg(py, px)-atan2Deg(dy, dx)) / 360
+	case ShapeDiamond:
+		if radius := math.Abs(dx) + math.Abs(dy); radius > 0 {
+			t = (math.Abs(px) + math.Abs(py)) / radius
+		}
+	default:
+		if length := (dx * dx) + (dy * dy); length > 0 {
+			t = ((px * dx) + (py * dy)) / length
+		}
+	}
+	return r.Spread.apply(t)
+}
+
+func (r GradientRenderer) RenderFA(dst *ImageFA) {
+	lut := r.Gradient.LUT(r.lutSize())
+	eachPixel(dst.Rect, func(x, y int) {
+		dst.Pix[dst.PixOffset(x, y)] = lut[r.lutIndex(x, y, len(lut))]
+	})
+}
+
+func (r GradientRenderer) Render32(dst *Image32) {
+	lut := r.Gradient.LUT(r.lutSize())
+	lut32 := toColor32Slice(lut)
+	eachPixel(dst.Rect, func(x, y int) {
+		i := r.lutIndex(x, y, len(lut))
+		if r.Dither {
+			dst.Pix[dst.PixOffset(x, y)] = ditherColor32(lut[i], x, y)
+		} else {
+			dst.Pix[dst.PixOffset(x, y)] = lut32[i]
+		}
+	})
+}
+
+// Render draws into any draw.Image, using the typed paths for ImageFA and
+// Image32. Other images receive dithered colors when Dither is set.
+func (r GradientRenderer) Render(dst draw.Image) {
+	switch dst := dst.(type) {
+	case *ImageFA:
+		r.RenderFA(dst)
+		return
+	case *Image32:
+		r.Render32(dst)
+		return
+	}
+	lut := r.Gradient.LUT(r.lutSize())
+	eachPixel(dst.Bounds(), func(x, y int) {
+		c := lut[r.lutIndex(x, y, len(lut))]
+		if r.Dither {
+			dst.Set(x, y, ditherColor32(c, x, y).Std())
+		} else {
+			dst.Set(x, y, c.Std())
+		}
+	})
+}
+
+/******************
+	GRADIENT_SHAPE
+*******************/
+
+func (s GradientShape) String() string {
+	if s >= shapeCount {
+		return fmt.Sprintf("GradientShape(%d)", uint8(s))
+	}
+	return shapeNames[s]
+}
+
+/******************
+	SPREAD_MODE
+*******************/
+
+func (s SpreadMode) String() string {
+	if s >= spreadCount {
+		return fmt.Sprintf("SpreadMode(%d)", uint8(s))
+	}
+	return spreadNames[s]
+}
+
+/******************
+	INTERNAL
+*******************/
+
+var shapeNames = [shapeCount]string{
+	ShapeLinear:  "linear",
+	ShapeRadial:  "radial",
+	ShapeConic:   "conic",
+	ShapeDiamond: "diamond",
+}
+
+var spreadNames = [spreadCount]string{
+	SpreadPad:     "pad",
+	SpreadRepeat:  "repeat",
+	SpreadReflect: "reflect",
+}
+
+var bayer4 = [4][4]float32{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+func (s SpreadMode) apply(t float32) float32 {
+	switch s {
+	case SpreadRepeat:
+		return t - math.Floor(t)
+	case SpreadReflect:
+		m := math.FMod(math.Abs(t), 2)
+		if m > 1 {
+			return 2 - m
+		}
+		return m
+	}
+	return math.Clamp(minF, t, maxF)
+}
+
+func toColor32Slice(pix []ColorFA) []Color32 {
+	out := make([]Color32, len(pix))
+	for i, c := range pix {
+		out[i] = c.ToColor32()
+	}
+	return out
+}
+
+func (r GradientRenderer) lutSize() int {
+	if r.LUTSize <= 0 {
+		return defaultLUTSize
+	}
+	return r.LUTSize
+}
+
+func (r GradientRenderer) lutIndex(x int, y int, n int) int {
+	t := r.T(float32(x)+0.5, float32(y)+0.5)
+	if t != t {
+		t = 0
+	}
+	return int(math.RoundClamp(0, t*float32(n-1), float32(n-1)))
+}
+
+func ditherColor32(c ColorFA, x int, y int) Color32 {
+	off := ((bayer4[y&3][x&3] + 0.5) / 16) - 0.5
+	rr := math.RoundClamp(min32, (c[0]*max32)+off, max32)
+	gg := math.RoundClamp(min32, (c[1]*max32)+off, max32)
+	bb := math.RoundClamp(min32, (c[2]*max32)+off, max32)
+	aa := math.RoundClamp(min32, c[3]*max32, max32)
+	return Color32(rr)<<24 | Color32(gg)<<16 | Color32(bb)<<8 | Color32(aa)
+}
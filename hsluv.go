@@ -0,0 +1,119 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// HSLuv and HPLuv are defined over CIE LCh(uv) with a D65 white. Saturation
+// and lightness run 0-1 here to match NewColorHSVA rather than the 0-100 of
+// the reference implementation. HPLuv's p exceeds 1 for colors outside the
+// pastel range, so NewColorHPLuv only limits the resulting chroma to sRGB.
+
+/******************
+	COLOR_FA
+*******************/
+
+func NewColorHSLuv(h float32, s float32, l float32, a float32) ColorFA {
+	h = math.Clamp(0, h, 360)
+	s = math.Clamp(0, s, 1)
+	l = math.Clamp(0, l, 1) * 100
+	a = math.Clamp(0, a, 1)
+	if l >= 100-epsilon {
+		return ColorFA{1, 1, 1, a}
+	}
+	if l <= epsilon {
+		return ColorFA{0, 0, 0, a}
+	}
+	chroma := maxChromaForLH(l, h) * s
+	return LChuv{l, chroma, h, a}.ToColorFA(WhiteD65)
+}
+
+func NewColorHPLuv(h float32, p float32, l float32, a float32) ColorFA {
+	h = math.Clamp(0, h, 360)
+	p = math.Max(0, p)
+	l = math.Clamp(0, l, 1) * 100
+	a = math.Clamp(0, a, 1)
+	if l >= 100-epsilon {
+		return ColorFA{1, 1, 1, a}
+	}
+	if l <= epsilon {
+		return ColorFA{0, 0, 0, a}
+	}
+	chroma := math.Min(maxSafeChromaForL(l)*p, maxChromaForLH(l, h))
+	return LChuv{l, chroma, h, a}.ToColorFA(WhiteD65)
+}
+
+func (c ColorFA) HSLuv() (h float32, s float32, l float32, a float32) {
+	lch := c.ToLChuv(WhiteD65)
+	l, h, a = lch[0], lch[2], lch[3]
+	if l >= 100-epsilon || l <= epsilon {
+		return h, 0, l / 100, a
+	}
+	s = math.Clamp(0, lch[1]/maxChromaForLH(l, h), 1)
+	return h, s, l / 100, a
+}
+
+func (c ColorFA) HPLuv() (h float32, p float32, l float32, a float32) {
+	lch := c.ToLChuv(WhiteD65)
+	l, h, a = lch[0], lch[2], lch[3]
+	if l >= 100-epsilon || l <= epsilon {
+		return h, 0, l / 100, a
+	}
+	p = lch[1] / maxSafeChromaForL(l)
+	return h, p, l / 100, a
+}
+
+/******************
+	INTERNAL
+*******************/
+
+type boundLine struct {
+	slope     float32
+	intercept float32
+}
+
+// hsluvBounds returns, for a Luv lightness, the six lines v = slope*u +
+// intercept in the uv plane where one sRGB channel reaches 0 or 1.
+func hsluvBounds(l float32) [6]boundLine {
+	var lines [6]boundLine
+	ll := float64(l)
+	y := math.Cube((ll + 16) / 116)
+	if y <= labEpsilon {
+		y = ll / labKappa
+	}
+	un32, vn32 := uvPrime(WhiteD65[0], WhiteD65[1], WhiteD65[2])
+	un, vn := float64(un32), float64(vn32)
+	for i, row := range xyzToSRGB {
+		m1, m2, m3 := float64(row[0]), float64(row[1]), float64(row[2])
+		for t := 0; t < 2; t += 1 {
+			a := y * ((9 * m1) - (3 * m3))
+			b := (y * ((4 * m2) - (20 * m3))) - (4 * float64(t))
+			c := 12 * m3 * y
+			lines[(i*2)+t] = boundLine{float32(-a / b), float32(-13 * ll * ((a * un) + (b * vn) + c) / b)}
+		}
+	}
+	return lines
+}
+
+func maxChromaForLH(l float32, h float32) float32 {
+	sin, cos := math.SinDeg(h), math.CosDeg(h)
+	var min float32 = math.MAX_F32
+	for _, line := range hsluvBounds(l) {
+		length := line.intercept / (sin - (line.slope * cos))
+		if length >= 0 && length < min {
+			min = length
+		}
+	}
+	return min
+}
+
+func maxSafeChromaForL(l float32) float32 {
+	var min float32 = math.MAX_F32
+	for _, line := range hsluvBounds(l) {
+		dist := math.Abs(line.intercept) / sqrt((line.slope*line.slope)+1)
+		if dist < min {
+			min = dist
+		}
+	}
+	return min
+}
@@ -0,0 +1,430 @@
+package color
+
+import (
+	"image"
+	stdcolor "image/color"
+)
+
+// Image types store one packed color per pixel, so Stride is measured in
+// pixels rather than bytes.
+type ImageFA struct {
+	Pix    []ColorFA
+	Stride int
+	Rect   image.Rectangle
+}
+type Image64 struct {
+	Pix    []Color64
+	Stride int
+	Rect   image.Rectangle
+}
+type Image32 struct {
+	Pix    []Color32
+	Stride int
+	Rect   image.Rectangle
+}
+
+/******************
+	IMAGE_FA
+*******************/
+
+func NewImageFA(r image.Rectangle) *ImageFA {
+	return &ImageFA{
+		Pix:    make([]ColorFA, pixelCount(r)),
+		Stride: r.Dx(),
+		Rect:   r,
+	}
+}
+
+func NewImageFAFrom(src image.Image) *ImageFA {
+	r := src.Bounds()
+	p := NewImageFA(r)
+	switch s := src.(type) {
+	case *ImageFA:
+		copyRows(p.Pix, p.Stride, s.Pix, s.Stride, s.PixOffset(r.Min.X, r.Min.Y), r)
+	case *Image64:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = s.Color64At(x, y).ToColorFA()
+		})
+	case *Image32:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = s.Color32At(x, y).ToColorFA()
+		})
+	case *image.NRGBA:
+		eachPixel(r, func(x, y int) {
+			n := s.NRGBAAt(x, y)
+			p.Pix[p.PixOffset(x, y)] = nrgbaToColor32(n).ToColorFA()
+		})
+	default:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = NewColorStd(src.At(x, y))
+		})
+	}
+	return p
+}
+
+func (p *ImageFA) ColorModel() stdcolor.Model {
+	return ModelFA
+}
+
+func (p *ImageFA) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+func (p *ImageFA) At(x int, y int) stdcolor.Color {
+	return p.ColorFAAt(x, y).Std()
+}
+
+func (p *ImageFA) ColorFAAt(x int, y int) ColorFA {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return ColorFA{}
+	}
+	return p.Pix[p.PixOffset(x, y)]
+}
+
+func (p *ImageFA) PixOffset(x int, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x - p.Rect.Min.X)
+}
+
+func (p *ImageFA) Set(x int, y int, c stdcolor.Color) {
+	p.SetColorFA(x, y, NewColorStd(c))
+}
+
+func (p *ImageFA) SetColorFA(x int, y int, c ColorFA) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.Pix[p.PixOffset(x, y)] = c
+}
+
+func (p *ImageFA) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &ImageFA{}
+	}
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &ImageFA{
+		Pix:    p.Pix[i:],
+		Stride: p.Stride,
+		Rect:   r,
+	}
+}
+
+func (p *ImageFA) Opaque() bool {
+	if p.Rect.Empty() {
+		return true
+	}
+	opaque := true
+	eachPixel(p.Rect, func(x, y int) {
+		if p.Pix[p.PixOffset(x, y)][3] < maxF {
+			opaque = false
+		}
+	})
+	return opaque
+}
+
+func (p *ImageFA) Map(mapFunc func(ColorFA) ColorFA) {
+	eachPixel(p.Rect, func(x, y int) {
+		i := p.PixOffset(x, y)
+		p.Pix[i] = mapFunc(p.Pix[i])
+	})
+}
+
+func (p *ImageFA) Blend(other *ImageFA, blendFunc func(ColorFA, ColorFA) ColorFA) {
+	eachPixel(p.Rect.Intersect(other.Rect), func(x, y int) {
+		i := p.PixOffset(x, y)
+		p.Pix[i] = blendFunc(p.Pix[i], other.Pix[other.PixOffset(x, y)])
+	})
+}
+
+func (p *ImageFA) ToImage64() *Image64 {
+	dst := NewImage64(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		dst.Pix[dst.PixOffset(x, y)] = p.Pix[p.PixOffset(x, y)].ToColor64()
+	})
+	return dst
+}
+
+func (p *ImageFA) ToImage32() *Image32 {
+	dst := NewImage32(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		dst.Pix[dst.PixOffset(x, y)] = p.Pix[p.PixOffset(x, y)].ToColor32()
+	})
+	return dst
+}
+
+func (p *ImageFA) ToNRGBA64() *image.NRGBA64 {
+	return p.ToImage64().ToNRGBA64()
+}
+
+func (p *ImageFA) ToNRGBA() *image.NRGBA {
+	return p.ToImage32().ToNRGBA()
+}
+
+func (p *ImageFA) ToRGBA64() *image.RGBA64 {
+	dst := image.NewRGBA64(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		r, g, b, a := p.Pix[p.PixOffset(x, y)].Std().RGBA()
+		dst.SetRGBA64(x, y, stdcolor.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: uint16(a)})
+	})
+	return dst
+}
+
+func (p *ImageFA) ToRGBA() *image.RGBA {
+	dst := image.NewRGBA(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		r, g, b, a := p.Pix[p.PixOffset(x, y)].Std().RGBA()
+		dst.SetRGBA(x, y, stdcolor.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)})
+	})
+	return dst
+}
+
+/******************
+	IMAGE_64
+*******************/
+
+func NewImage64(r image.Rectangle) *Image64 {
+	return &Image64{
+		Pix:    make([]Color64, pixelCount(r)),
+		Stride: r.Dx(),
+		Rect:   r,
+	}
+}
+
+func NewImage64From(src image.Image) *Image64 {
+	r := src.Bounds()
+	p := NewImage64(r)
+	switch s := src.(type) {
+	case *Image64:
+		copyRows(p.Pix, p.Stride, s.Pix, s.Stride, s.PixOffset(r.Min.X, r.Min.Y), r)
+	case *ImageFA:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = s.ColorFAAt(x, y).ToColor64()
+		})
+	case *image.NRGBA64:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = nrgba64ToColor64(s.NRGBA64At(x, y))
+		})
+	default:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = Color64(model64(src.At(x, y)).(Std64))
+		})
+	}
+	return p
+}
+
+func (p *Image64) ColorModel() stdcolor.Model {
+	return Model64
+}
+
+func (p *Image64) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+func (p *Image64) At(x int, y int) stdcolor.Color {
+	return p.Color64At(x, y).Std()
+}
+
+func (p *Image64) Color64At(x int, y int) Color64 {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return 0
+	}
+	return p.Pix[p.PixOffset(x, y)]
+}
+
+func (p *Image64) PixOffset(x int, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x - p.Rect.Min.X)
+}
+
+func (p *Image64) Set(x int, y int, c stdcolor.Color) {
+	p.SetColor64(x, y, Color64(model64(c).(Std64)))
+}
+
+func (p *Image64) SetColor64(x int, y int, c Color64) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.Pix[p.PixOffset(x, y)] = c
+}
+
+func (p *Image64) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &Image64{}
+	}
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &Image64{
+		Pix:    p.Pix[i:],
+		Stride: p.Stride,
+		Rect:   r,
+	}
+}
+
+func (p *Image64) Opaque() bool {
+	if p.Rect.Empty() {
+		return true
+	}
+	opaque := true
+	eachPixel(p.Rect, func(x, y int) {
+		if p.Pix[p.PixOffset(x, y)]&max64 != max64 {
+			opaque = false
+		}
+	})
+	return opaque
+}
+
+func (p *Image64) ToImageFA() *ImageFA {
+	return NewImageFAFrom(p)
+}
+
+func (p *Image64) ToNRGBA64() *image.NRGBA64 {
+	dst := image.NewNRGBA64(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		r, g, b, a := p.Pix[p.PixOffset(x, y)].RGBA()
+		dst.SetNRGBA64(x, y, stdcolor.NRGBA64{R: r, G: g, B: b, A: a})
+	})
+	return dst
+}
+
+/******************
+	IMAGE_32
+*******************/
+
+func NewImage32(r image.Rectangle) *Image32 {
+	return &Image32{
+		Pix:    make([]Color32, pixelCount(r)),
+		Stride: r.Dx(),
+		Rect:   r,
+	}
+}
+
+func NewImage32From(src image.Image) *Image32 {
+	r := src.Bounds()
+	p := NewImage32(r)
+	switch s := src.(type) {
+	case *Image32:
+		copyRows(p.Pix, p.Stride, s.Pix, s.Stride, s.PixOffset(r.Min.X, r.Min.Y), r)
+	case *ImageFA:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = s.ColorFAAt(x, y).ToColor32()
+		})
+	case *image.NRGBA:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = nrgbaToColor32(s.NRGBAAt(x, y))
+		})
+	default:
+		eachPixel(r, func(x, y int) {
+			p.Pix[p.PixOffset(x, y)] = Color32(model32(src.At(x, y)).(Std32))
+		})
+	}
+	return p
+}
+
+func (p *Image32) ColorModel() stdcolor.Model {
+	return Model32
+}
+
+func (p *Image32) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+func (p *Image32) At(x int, y int) stdcolor.Color {
+	return p.Color32At(x, y).Std()
+}
+
+func (p *Image32) Color32At(x int, y int) Color32 {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return 0
+	}
+	return p.Pix[p.PixOffset(x, y)]
+}
+
+func (p *Image32) PixOffset(x int, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x - p.Rect.Min.X)
+}
+
+func (p *Image32) Set(x int, y int, c stdcolor.Color) {
+	p.SetColor32(x, y, Color32(model32(c).(Std32)))
+}
+
+func (p *Image32) SetColor32(x int, y int, c Color32) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.Pix[p.PixOffset(x, y)] = c
+}
+
+func (p *Image32) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &Image32{}
+	}
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &Image32{
+		Pix:    p.Pix[i:],
+		Stride: p.Stride,
+		Rect:   r,
+	}
+}
+
+func (p *Image32) Opaque() bool {
+	if p.Rect.Empty() {
+		return true
+	}
+	opaque := true
+	eachPixel(p.Rect, func(x, y int) {
+		if p.Pix[p.PixOffset(x, y)]&max32 != max32 {
+			opaque = false
+		}
+	})
+	return opaque
+}
+
+func (p *Image32) ToImageFA() *ImageFA {
+	return NewImageFAFrom(p)
+}
+
+func (p *Image32) ToNRGBA() *image.NRGBA {
+	dst := image.NewNRGBA(p.Rect)
+	eachPixel(p.Rect, func(x, y int) {
+		r, g, b, a := p.Pix[p.PixOffset(x, y)].RGBA()
+		dst.SetNRGBA(x, y, stdcolor.NRGBA{R: r, G: g, B: b, A: a})
+	})
+	return dst
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func pixelCount(r image.Rectangle) int {
+	if r.Empty() {
+		return 0
+	}
+	return r.Dx() * r.Dy()
+}
+
+func eachPixel(r image.Rectangle, pixelFunc func(x int, y int)) {
+	for y := r.Min.Y; y < r.Max.Y; y += 1 {
+		for x := r.Min.X; x < r.Max.X; x += 1 {
+			pixelFunc(x, y)
+		}
+	}
+}
+
+func copyRows[T any](dst []T, dstStride int, src []T, srcStride int, srcOff int, r image.Rectangle) {
+	w := r.Dx()
+	dstOff := 0
+	for y := r.Min.Y; y < r.Max.Y; y += 1 {
+		copy(dst[dstOff:dstOff+w], src[srcOff:srcOff+w])
+		dstOff += dstStride
+		srcOff += srcStride
+	}
+}
+
+func nrgbaToColor32(n stdcolor.NRGBA) Color32 {
+	return Color32(n.R)<<24 | Color32(n.G)<<16 | Color32(n.B)<<8 | Color32(n.A)
+}
+
+func nrgba64ToColor64(n stdcolor.NRGBA64) Color64 {
+	return Color64(n.R)<<48 | Color64(n.G)<<32 | Color64(n.B)<<16 | Color64(n.A)
+}
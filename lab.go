@@ -0,0 +1,154 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	labEpsilon = 216.0 / 24389.0
+	labKappa   = 24389.0 / 27.0
+)
+
+// Lab holds CIE L*a*b* (L in 0-100) followed by straight alpha.
+type Lab [4]float32
+
+// LCh holds CIE LCh(ab) (hue in degrees) followed by straight alpha.
+type LCh [4]float32
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToLab(white WhitePoint) Lab {
+	return c.ToXYZ(white).ToLab(white)
+}
+
+func (c ColorFA) ToLCh(white WhitePoint) LCh {
+	return c.ToLab(white).ToLCh()
+}
+
+/******************
+	XYZ
+*******************/
+
+func (c XYZ) ToLab(white WhitePoint) Lab {
+	fx := labF(c[0] / white[0])
+	fy := labF(c[1] / white[1])
+	fz := labF(c[2] / white[2])
+	return Lab{(116 * fy) - 16, 500 * (fx - fy), 200 * (fy - fz), c[3]}
+}
+
+/******************
+	LAB
+*******************/
+
+func (c Lab) ToXYZ(white WhitePoint) XYZ {
+	fy := (c[0] + 16) / 116
+	fx := fy + (c[1] / 500)
+	fz := fy - (c[2] / 200)
+	var yr float32
+	if c[0] > labKappa*labEpsilon {
+		yr = fy * fy * fy
+	} else {
+		yr = c[0] / labKappa
+	}
+	return XYZ{labFInv(fx) * white[0], yr * white[1], labFInv(fz) * white[2], c[3]}
+}
+
+func (c Lab) ToColorFA(white WhitePoint) ColorFA {
+	return c.ToXYZ(white).ToColorFA(white)
+}
+
+func (c Lab) ToLCh() LCh {
+	chroma := sqrt((c[1] * c[1]) + (c[2] * c[2]))
+	if chroma < epsilon {
+		return LCh{c[0], chroma, 0, c[3]}
+	}
+	return LCh{c[0], chroma, atan2Deg(c[2], c[1]), c[3]}
+}
+
+func (c Lab) L() float32 {
+	return c[0]
+}
+func (c Lab) A() float32 {
+	return c[1]
+}
+func (c Lab) B() float32 {
+	return c[2]
+}
+func (c Lab) Alpha() float32 {
+	return c[3]
+}
+
+func (c Lab) SetL(l float32) Lab {
+	return Lab{l, c[1], c[2], c[3]}
+}
+func (c Lab) SetA(a float32) Lab {
+	return Lab{c[0], a, c[2], c[3]}
+}
+func (c Lab) SetB(b float32) Lab {
+	return Lab{c[0], c[1], b, c[3]}
+}
+func (c Lab) SetAlpha(alpha float32) Lab {
+	return Lab{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	LCH
+*******************/
+
+func (c LCh) ToLab() Lab {
+	return Lab{c[0], c[1] * math.CosDeg(c[2]), c[1] * math.SinDeg(c[2]), c[3]}
+}
+
+func (c LCh) ToColorFA(white WhitePoint) ColorFA {
+	return c.ToLab().ToColorFA(white)
+}
+
+func (c LCh) L() float32 {
+	return c[0]
+}
+func (c LCh) Chroma() float32 {
+	return c[1]
+}
+func (c LCh) Hue() float32 {
+	return c[2]
+}
+func (c LCh) Alpha() float32 {
+	return c[3]
+}
+
+func (c LCh) SetL(l float32) LCh {
+	return LCh{l, c[1], c[2], c[3]}
+}
+func (c LCh) SetChroma(chroma float32) LCh {
+	return LCh{c[0], math.Max(0, chroma), c[2], c[3]}
+}
+func (c LCh) SetHue(hue float32) LCh {
+	return LCh{c[0], c[1], wrapHue(hue), c[3]}
+}
+func (c LCh) SetChromaHue(chroma float32, hue float32) LCh {
+	return LCh{c[0], math.Max(0, chroma), wrapHue(hue), c[3]}
+}
+func (c LCh) SetAlpha(alpha float32) LCh {
+	return LCh{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func labF(t float32) float32 {
+	if t > labEpsilon {
+		return cbrt(t)
+	}
+	return ((labKappa * t) + 16) / 116
+}
+
+func labFInv(f float32) float32 {
+	f3 := f * f * f
+	if f3 > labEpsilon {
+		return f3
+	}
+	return ((116 * f) - 16) / labKappa
+}
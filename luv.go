@@ -0,0 +1,157 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// Luv holds CIE L*u*v* (L in 0-100) followed by straight alpha.
+type Luv [4]float32
+
+// LChuv holds CIE LCh(uv) (hue in degrees) followed by straight alpha.
+type LChuv [4]float32
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToLuv(white WhitePoint) Luv {
+	return c.ToXYZ(white).ToLuv(white)
+}
+
+func (c ColorFA) ToLChuv(white WhitePoint) LChuv {
+	return c.ToLuv(white).ToLChuv()
+}
+
+/******************
+	XYZ
+*******************/
+
+func (c XYZ) ToLuv(white WhitePoint) Luv {
+	yr := c[1] / white[1]
+	var l float32
+	if yr > labEpsilon {
+		l = (116 * cbrt(yr)) - 16
+	} else {
+		l = labKappa * yr
+	}
+	if l <= 0 {
+		return Luv{0, 0, 0, c[3]}
+	}
+	u, v := uvPrime(c[0], c[1], c[2])
+	un, vn := uvPrime(white[0], white[1], white[2])
+	return Luv{l, 13 * l * (u - un), 13 * l * (v - vn), c[3]}
+}
+
+/******************
+	LUV
+*******************/
+
+func (c Luv) ToXYZ(white WhitePoint) XYZ {
+	if c[0] <= 0 {
+		return XYZ{0, 0, 0, c[3]}
+	}
+	un, vn := uvPrime(white[0], white[1], white[2])
+	u := (c[1] / (13 * c[0])) + un
+	v := (c[2] / (13 * c[0])) + vn
+	var y float32
+	if c[0] > labKappa*labEpsilon {
+		fy := (c[0] + 16) / 116
+		y = white[1] * fy * fy * fy
+	} else {
+		y = white[1] * c[0] / labKappa
+	}
+	x := y * 9 * u / (4 * v)
+	z := y * (12 - (3 * u) - (20 * v)) / (4 * v)
+	return XYZ{x, y, z, c[3]}
+}
+
+func (c Luv) ToColorFA(white WhitePoint) ColorFA {
+	return c.ToXYZ(white).ToColorFA(white)
+}
+
+func (c Luv) ToLChuv() LChuv {
+	chroma := sqrt((c[1] * c[1]) + (c[2] * c[2]))
+	if chroma < epsilon {
+		return LChuv{c[0], chroma, 0, c[3]}
+	}
+	return LChuv{c[0], chroma, atan2Deg(c[2], c[1]), c[3]}
+}
+
+func (c Luv) L() float32 {
+	return c[0]
+}
+func (c Luv) U() float32 {
+	return c[1]
+}
+func (c Luv) V() float32 {
+	return c[2]
+}
+func (c Luv) Alpha() float32 {
+	return c[3]
+}
+
+func (c Luv) SetL(l float32) Luv {
+	return Luv{l, c[1], c[2], c[3]}
+}
+func (c Luv) SetU(u float32) Luv {
+	return Luv{c[0], u, c[2], c[3]}
+}
+func (c Luv) SetV(v float32) Luv {
+	return Luv{c[0], c[1], v, c[3]}
+}
+func (c Luv) SetAlpha(alpha float32) Luv {
+	return Luv{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	LCH_UV
+*******************/
+
+func (c LChuv) ToLuv() Luv {
+	return Luv{c[0], c[1] * math.CosDeg(c[2]), c[1] * math.SinDeg(c[2]), c[3]}
+}
+
+func (c LChuv) ToColorFA(white WhitePoint) ColorFA {
+	return c.ToLuv().ToColorFA(white)
+}
+
+func (c LChuv) L() float32 {
+	return c[0]
+}
+func (c LChuv) Chroma() float32 {
+	return c[1]
+}
+func (c LChuv) Hue() float32 {
+	return c[2]
+}
+func (c LChuv) Alpha() float32 {
+	return c[3]
+}
+
+func (c LChuv) SetL(l float32) LChuv {
+	return LChuv{l, c[1], c[2], c[3]}
+}
+func (c LChuv) SetChroma(chroma float32) LChuv {
+	return LChuv{c[0], math.Max(0, chroma), c[2], c[3]}
+}
+func (c LChuv) SetHue(hue float32) LChuv {
+	return LChuv{c[0], c[1], wrapHue(hue), c[3]}
+}
+func (c LChuv) SetChromaHue(chroma float32, hue float32) LChuv {
+	return LChuv{c[0], math.Max(0, chroma), wrapHue(hue), c[3]}
+}
+func (c LChuv) SetAlpha(alpha float32) LChuv {
+	return LChuv{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func uvPrime(x float32, y float32, z float32) (u float32, v float32) {
+	denom := x + (15 * y) + (3 * z)
+	if denom == 0 {
+		return 0, 0
+	}
+	return 4 * x / denom, 9 * y / denom
+}
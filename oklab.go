@@ -0,0 +1,197 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// Oklab holds Björn Ottosson's Oklab (L in 0-1) followed by straight alpha.
+type Oklab [4]float32
+
+// Oklch holds the polar form of Oklab (hue in degrees) followed by
+// straight alpha.
+type Oklch [4]float32
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToOklab() Oklab {
+	lin := c.ToLinear()
+	l, m, s := linearToLMS.mul(lin[0], lin[1], lin[2])
+	L, a, b := lmsToOklab.mul(cbrt(l), cbrt(m), cbrt(s))
+	return Oklab{L, a, b, c[3]}
+}
+
+func (c ColorFA) ToOklch() Oklch {
+	return c.ToOklab().ToOklch()
+}
+
+func (c ColorFA) OkLightness() float32 {
+	return c.ToOklab()[0]
+}
+func (c ColorFA) OkChroma() float32 {
+	return c.ToOklch()[1]
+}
+func (c ColorFA) OkHue() float32 {
+	return c.ToOklch()[2]
+}
+
+func (c ColorFA) SetOkLightness(light float32) ColorFA {
+	return c.ToOklch().SetL(light).MapToGamut().ToColorFA()
+}
+func (c ColorFA) SetOkChroma(chroma float32) ColorFA {
+	return c.ToOklch().SetChroma(chroma).MapToGamut().ToColorFA()
+}
+func (c ColorFA) SetOkHue(hue float32) ColorFA {
+	return c.ToOklch().SetHue(hue).MapToGamut().ToColorFA()
+}
+
+/******************
+	OKLAB
+*******************/
+
+func (c Oklab) ToColorFA() ColorFA {
+	r, g, b := oklabToLinear(c[0], c[1], c[2])
+	return LinearColorFA{r, g, b, c[3]}.ToColorFA()
+}
+
+func (c Oklab) ToOklch() Oklch {
+	chroma := sqrt((c[1] * c[1]) + (c[2] * c[2]))
+	if chroma < epsilon {
+		return Oklch{c[0], chroma, 0, c[3]}
+	}
+	return Oklch{c[0], chroma, atan2Deg(c[2], c[1]), c[3]}
+}
+
+func (c Oklab) L() float32 {
+	return c[0]
+}
+func (c Oklab) A() float32 {
+	return c[1]
+}
+func (c Oklab) B() float32 {
+	return c[2]
+}
+func (c Oklab) Alpha() float32 {
+	return c[3]
+}
+
+func (c Oklab) SetL(l float32) Oklab {
+	return Oklab{l, c[1], c[2], c[3]}
+}
+func (c Oklab) SetA(a float32) Oklab {
+	return Oklab{c[0], a, c[2], c[3]}
+}
+func (c Oklab) SetB(b float32) Oklab {
+	return Oklab{c[0], c[1], b, c[3]}
+}
+func (c Oklab) SetAlpha(alpha float32) Oklab {
+	return Oklab{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	OKLCH
+*******************/
+
+func (c Oklch) ToOklab() Oklab {
+	return Oklab{c[0], c[1] * math.CosDeg(c[2]), c[1] * math.SinDeg(c[2]), c[3]}
+}
+
+func (c Oklch) ToColorFA() ColorFA {
+	return c.ToOklab().ToColorFA()
+}
+
+func (c Oklch) InGamut() bool {
+	lab := c.ToOklab()
+	r, g, b := oklabToLinear(lab[0], lab[1], lab[2])
+	return inUnit(r) && inUnit(g) && inUnit(b)
+}
+
+// MapToGamut reduces chroma until the color fits in sRGB, keeping
+// lightness and hue unchanged.
+func (c Oklch) MapToGamut() Oklch {
+	if c[0] >= 1 {
+		return Oklch{1, 0, c[2], c[3]}
+	}
+	if c[0] <= 0 {
+		return Oklch{0, 0, c[2], c[3]}
+	}
+	if c.InGamut() {
+		return c
+	}
+	lo, hi := float32(0), c[1]
+	for i := 0; i < 24; i += 1 {
+		mid := (lo + hi) / 2
+		if c.SetChroma(mid).InGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return c.SetChroma(lo)
+}
+
+func (c Oklch) L() float32 {
+	return c[0]
+}
+func (c Oklch) Chroma() float32 {
+	return c[1]
+}
+func (c Oklch) Hue() float32 {
+	return c[2]
+}
+func (c Oklch) Alpha() float32 {
+	return c[3]
+}
+
+func (c Oklch) SetL(l float32) Oklch {
+	return Oklch{l, c[1], c[2], c[3]}
+}
+func (c Oklch) SetChroma(chroma float32) Oklch {
+	return Oklch{c[0], math.Max(0, chroma), c[2], c[3]}
+}
+func (c Oklch) SetHue(hue float32) Oklch {
+	return Oklch{c[0], c[1], wrapHue(hue), c[3]}
+}
+func (c Oklch) SetChromaHue(chroma float32, hue float32) Oklch {
+	return Oklch{c[0], math.Max(0, chroma), wrapHue(hue), c[3]}
+}
+func (c Oklch) SetAlpha(alpha float32) Oklch {
+	return Oklch{c[0], c[1], c[2], alpha}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+var (
+	linearToLMS = matrix3{
+		{0.4122214708, 0.5363325363, 0.0514459929},
+		{0.2119034982, 0.6806995451, 0.1073969566},
+		{0.0883024619, 0.2817188376, 0.6299787005},
+	}
+	lmsToOklab = matrix3{
+		{0.2104542553, 0.7936177850, -0.0040720468},
+		{1.9779984951, -2.4285922050, 0.4505937099},
+		{0.0259040371, 0.7827717662, -0.8086757660},
+	}
+	oklabToLMS = matrix3{
+		{1, 0.3963377774, 0.2158037573},
+		{1, -0.1055613458, -0.0638541728},
+		{1, -0.0894841775, -1.2914855480},
+	}
+	lmsToLinear = matrix3{
+		{4.0767416621, -3.3077115913, 0.2309699292},
+		{-1.2684380046, 2.6097574011, -0.3413193965},
+		{-0.0041960863, -0.7034186147, 1.7076147010},
+	}
+)
+
+func oklabToLinear(L float32, a float32, b float32) (float32, float32, float32) {
+	l, m, s := oklabToLMS.mul(L, a, b)
+	return lmsToLinear.mul(l*l*l, m*m*m, s*s*s)
+}
+
+func inUnit(v float32) bool {
+	return v >= -epsilon && v <= maxF+epsilon
+}
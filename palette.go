@@ -0,0 +1,174 @@
+package color
+
+import (
+	"sort"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+// DefaultPaletteThreshold is the ΔEOK below which AnalyzePalette flags a
+// pair of colors as too close to tell apart.
+const DefaultPaletteThreshold = 0.08
+
+// PaletteOptions configures AnalyzePalette. Zero values select DistanceEOK,
+// DefaultPaletteThreshold, full severity and the three dichromacies.
+// Threshold is in the units of Distance, so set both together.
+type PaletteOptions struct {
+	Distance     DistanceFunc
+	Threshold    float32
+	Deficiencies []Deficiency
+	Severity     float32
+	Candidates   []ColorFA
+}
+
+// PalettePair identifies two palette entries by index, A < B.
+type PalettePair struct {
+	A        int
+	B        int
+	Distance float32
+}
+
+// VisionReport covers one kind of vision. With fewer than two colors there
+// are no pairs: MinDistance is math.MAX_F32 and MinPair is {-1, -1}.
+type VisionReport struct {
+	Deficiency  Deficiency
+	MinDistance float32
+	MinPair     PalettePair
+	Offending   []PalettePair
+}
+
+// PaletteSuggestion proposes replacing the palette entry at Index with
+// Color, which would raise that entry's worst-case distance to MinDistance.
+type PaletteSuggestion struct {
+	Index       int
+	Color       ColorFA
+	MinDistance float32
+}
+
+// PaletteReport lists normal vision first, then each simulated deficiency.
+type PaletteReport struct {
+	Visions     []VisionReport
+	MinDistance float32
+	Suggestions []PaletteSuggestion
+}
+
+/******************
+	PALETTE
+*******************/
+
+func AnalyzePalette(palette []ColorFA, opts PaletteOptions) PaletteReport {
+	opts = opts.withDefaults()
+	visions := append([]Deficiency{NormalVision}, opts.Deficiencies...)
+	report := PaletteReport{MinDistance: math.MAX_F32}
+	offending := map[int]bool{}
+	for _, d := range visions {
+		sims := simulatePalette(palette, d, opts.Severity)
+		vr := VisionReport{Deficiency: d, MinDistance: math.MAX_F32, MinPair: PalettePair{-1, -1, math.MAX_F32}}
+		for a := 0; a < len(sims); a += 1 {
+			for b := a + 1; b < len(sims); b += 1 {
+				pair := PalettePair{a, b, opts.Distance(sims[a], sims[b])}
+				if pair.Distance < vr.MinDistance {
+					vr.MinDistance, vr.MinPair = pair.Distance, pair
+				}
+				if pair.Distance < opts.Threshold {
+					vr.Offending = append(vr.Offending, pair)
+					offending[b] = true
+				}
+			}
+		}
+		report.MinDistance = math.Min(report.MinDistance, vr.MinDistance)
+		report.Visions = append(report.Visions, vr)
+	}
+	if len(opts.Candidates) > 0 {
+		report.Suggestions = suggestReplacements(palette, offending, visions, opts)
+	}
+	return report
+}
+
+func (r PaletteReport) OK() bool {
+	for _, v := range r.Visions {
+		if len(v.Offending) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func (opts PaletteOptions) withDefaults() PaletteOptions {
+	if opts.Distance == nil {
+		opts.Distance = DistanceEOK
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultPaletteThreshold
+	}
+	if opts.Deficiencies == nil {
+		opts.Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+	}
+	if opts.Severity <= 0 {
+		opts.Severity = 1
+	}
+	return opts
+}
+
+func simulatePalette(palette []ColorFA, d Deficiency, severity float32) []ColorFA {
+	sims := make([]ColorFA, len(palette))
+	for i, c := range palette {
+		sims[i] = c.SimulateCVD(d, severity)
+	}
+	return sims
+}
+
+// worstDistance is the smallest distance from c to every entry but skip,
+// over all visions.
+func worstDistance(c ColorFA, skip int, palette []ColorFA, visions []Deficiency, opts PaletteOptions) float32 {
+	var worst float32 = math.MAX_F32
+	for _, d := range visions {
+		sim := c.SimulateCVD(d, opts.Severity)
+		for i, p := range palette {
+			if i != skip {
+				worst = math.Min(worst, opts.Distance(sim, p.SimulateCVD(d, opts.Severity)))
+			}
+		}
+	}
+	return worst
+}
+
+func suggestReplacements(palette []ColorFA, offending map[int]bool, visions []Deficiency, opts PaletteOptions) []PaletteSuggestion {
+	indexes := make([]int, 0, len(offending))
+	for i := range offending {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	working := append([]ColorFA(nil), palette...)
+	var suggestions []PaletteSuggestion
+	for _, i := range indexes {
+		best := PaletteSuggestion{Index: i, MinDistance: worstDistance(working[i], i, working, visions, opts)}
+		found := false
+		for _, cand := range opts.Candidates {
+			if containsColor(working, cand) {
+				continue
+			}
+			if dist := worstDistance(cand, i, working, visions, opts); dist > best.MinDistance {
+				best.Color, best.MinDistance, found = cand, dist, true
+			}
+		}
+		if found {
+			working[i] = best.Color
+			suggestions = append(suggestions, best)
+		}
+	}
+	return suggestions
+}
+
+func containsColor(palette []ColorFA, c ColorFA) bool {
+	for _, p := range palette {
+		if p == c {
+			return true
+		}
+	}
+	return false
+}
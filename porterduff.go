@@ -0,0 +1,138 @@
+package color
+
+// The Porter-Duff operators treat the receiver as the destination (the
+// backdrop) and the argument as the source, so dst.SrcOver(src) paints src
+// on top of dst. Results carry the correct composite alpha.
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) Clear(src ColorFA) ColorFA {
+	return c.Premultiply().Clear(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) Src(src ColorFA) ColorFA {
+	return c.Premultiply().Src(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) Dst(src ColorFA) ColorFA {
+	return c.Premultiply().Dst(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) SrcOver(src ColorFA) ColorFA {
+	return c.Premultiply().SrcOver(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) DstOver(src ColorFA) ColorFA {
+	return c.Premultiply().DstOver(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) SrcIn(src ColorFA) ColorFA {
+	return c.Premultiply().SrcIn(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) DstIn(src ColorFA) ColorFA {
+	return c.Premultiply().DstIn(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) SrcOut(src ColorFA) ColorFA {
+	return c.Premultiply().SrcOut(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) DstOut(src ColorFA) ColorFA {
+	return c.Premultiply().DstOut(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) SrcAtop(src ColorFA) ColorFA {
+	return c.Premultiply().SrcAtop(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) DstAtop(src ColorFA) ColorFA {
+	return c.Premultiply().DstAtop(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) Xor(src ColorFA) ColorFA {
+	return c.Premultiply().Xor(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) Plus(src ColorFA) ColorFA {
+	return c.Premultiply().Plus(src.Premultiply()).Unpremultiply()
+}
+func (c ColorFA) Lighter(src ColorFA) ColorFA {
+	return c.Plus(src)
+}
+
+/******************
+	PREMUL_FA
+*******************/
+
+func (c PremulFA) Clear(src PremulFA) PremulFA {
+	return PremulFA{0, 0, 0, 0}
+}
+func (c PremulFA) Src(src PremulFA) PremulFA {
+	return src
+}
+func (c PremulFA) Dst(src PremulFA) PremulFA {
+	return c
+}
+func (c PremulFA) SrcOver(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1, 1-src[3])
+}
+func (c PremulFA) DstOver(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1-c[3], 1)
+}
+func (c PremulFA) SrcIn(src PremulFA) PremulFA {
+	return porterDuff(c, src, c[3], 0)
+}
+func (c PremulFA) DstIn(src PremulFA) PremulFA {
+	return porterDuff(c, src, 0, src[3])
+}
+func (c PremulFA) SrcOut(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1-c[3], 0)
+}
+func (c PremulFA) DstOut(src PremulFA) PremulFA {
+	return porterDuff(c, src, 0, 1-src[3])
+}
+func (c PremulFA) SrcAtop(src PremulFA) PremulFA {
+	return porterDuff(c, src, c[3], 1-src[3])
+}
+func (c PremulFA) DstAtop(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1-c[3], src[3])
+}
+func (c PremulFA) Xor(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1-c[3], 1-src[3])
+}
+func (c PremulFA) Plus(src PremulFA) PremulFA {
+	return porterDuff(c, src, 1, 1)
+}
+func (c PremulFA) Lighter(src PremulFA) PremulFA {
+	return c.Plus(src)
+}
+
+/******************
+	SLICES
+*******************/
+
+// BlendSlice replaces each dst[i] with blendFunc(dst[i], src[i]) over the
+// shorter of the two slices, e.g. BlendSlice(dst, src, ColorFA.SrcOver).
+func BlendSlice(dst []ColorFA, src []ColorFA, blendFunc func(ColorFA, ColorFA) ColorFA) {
+	n := len(dst)
+	if len(src) < n {
+		n = len(src)
+	}
+	for i := 0; i < n; i += 1 {
+		dst[i] = blendFunc(dst[i], src[i])
+	}
+}
+
+func BlendPremulSlice(dst []PremulFA, src []PremulFA, blendFunc func(PremulFA, PremulFA) PremulFA) {
+	n := len(dst)
+	if len(src) < n {
+		n = len(src)
+	}
+	for i := 0; i < n; i += 1 {
+		dst[i] = blendFunc(dst[i], src[i])
+	}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func porterDuff(dst PremulFA, src PremulFA, srcFactor float32, dstFactor float32) PremulFA {
+	return PremulFA{
+		(src[0] * srcFactor) + (dst[0] * dstFactor),
+		(src[1] * srcFactor) + (dst[1] * dstFactor),
+		(src[2] * srcFactor) + (dst[2] * dstFactor),
+		(src[3] * srcFactor) + (dst[3] * dstFactor),
+	}.Clamp()
+}
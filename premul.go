@@ -0,0 +1,68 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+// PremulFA holds red, green and blue already multiplied by alpha. Sums and
+// interpolations of premultiplied colors are exact compositing operations,
+// which is what GPU buffers and image.RGBA store.
+type PremulFA [4]float32
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) Premultiply() PremulFA {
+	return PremulFA{c[0] * c[3], c[1] * c[3], c[2] * c[3], c[3]}
+}
+
+/******************
+	PREMUL_FA
+*******************/
+
+func (c PremulFA) Unpremultiply() ColorFA {
+	if c[3] <= 0 {
+		return ColorFA{0, 0, 0, 0}
+	}
+	return ColorFA{c[0] / c[3], c[1] / c[3], c[2] / c[3], c[3]}.Clamp()
+}
+
+func (c PremulFA) RGBA() (r float32, g float32, b float32, a float32) {
+	return c[0], c[1], c[2], c[3]
+}
+
+func (c PremulFA) Red() float32 {
+	return c[0]
+}
+func (c PremulFA) Green() float32 {
+	return c[1]
+}
+func (c PremulFA) Blue() float32 {
+	return c[2]
+}
+func (c PremulFA) Alpha() float32 {
+	return c[3]
+}
+
+func (c PremulFA) Add(other PremulFA) PremulFA {
+	return PremulFA{c[0] + other[0], c[1] + other[1], c[2] + other[2], c[3] + other[3]}.Clamp()
+}
+func (c PremulFA) Subtract(other PremulFA) PremulFA {
+	return PremulFA{c[0] - other[0], c[1] - other[1], c[2] - other[2], c[3] - other[3]}.Clamp()
+}
+func (c PremulFA) Multiply(other PremulFA) PremulFA {
+	return PremulFA{c[0] * other[0], c[1] * other[1], c[2] * other[2], c[3] * other[3]}.Clamp()
+}
+func (c PremulFA) Scale(amount float32) PremulFA {
+	return PremulFA{c[0] * amount, c[1] * amount, c[2] * amount, c[3] * amount}.Clamp()
+}
+func (c PremulFA) Blend(ratio float32, other PremulFA) PremulFA {
+	return PremulFA{lerp(c[0], other[0], ratio), lerp(c[1], other[1], ratio), lerp(c[2], other[2], ratio), lerp(c[3], other[3], ratio)}.Clamp()
+}
+
+// Clamp limits alpha to 0-1 and each color component to 0-alpha.
+func (c PremulFA) Clamp() PremulFA {
+	a := math.Clamp(minF, c[3], maxF)
+	return PremulFA{math.Clamp(minF, c[0], a), math.Clamp(minF, c[1], a), math.Clamp(minF, c[2], a), a}
+}
@@ -0,0 +1,261 @@
+package color
+
+import (
+	stdcolor "image/color"
+
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	maxStd = 0xffff
+)
+
+// The Std types wrap each color type so it satisfies image/color.Color,
+// whose RGBA method returns alpha-premultiplied 16-bit values. Models for
+// the opaque types (F, 48, 24) drop alpha the way image/color.GrayModel does.
+type StdFA ColorFA
+type StdF ColorF
+type Std64 Color64
+type Std48 Color48
+type Std32 Color32
+type Std24 Color24
+type Std16 Color16
+type Std8 Color8
+type StdPremulFA PremulFA
+
+var (
+	ModelFA stdcolor.Model = stdcolor.ModelFunc(modelFA)
+	ModelF  stdcolor.Model = stdcolor.ModelFunc(modelF)
+	Model64 stdcolor.Model = stdcolor.ModelFunc(model64)
+	Model48 stdcolor.Model = stdcolor.ModelFunc(model48)
+	Model32 stdcolor.Model = stdcolor.ModelFunc(model32)
+	Model24 stdcolor.Model = stdcolor.ModelFunc(model24)
+	Model16 stdcolor.Model = stdcolor.ModelFunc(model16)
+	Model8  stdcolor.Model = stdcolor.ModelFunc(model8)
+
+	ModelPremulFA stdcolor.Model = stdcolor.ModelFunc(modelPremulFA)
+)
+
+func NewColorStd(c stdcolor.Color) ColorFA {
+	switch c := c.(type) {
+	case StdFA:
+		return ColorFA(c)
+	case StdF:
+		return ColorF(c).ToColorFA()
+	}
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return ColorFA{0, 0, 0, 0}
+	}
+	fa := float32(a)
+	rr := math.Clamp(minF, float32(r)/fa, maxF)
+	gg := math.Clamp(minF, float32(g)/fa, maxF)
+	bb := math.Clamp(minF, float32(b)/fa, maxF)
+	aa := math.Clamp(minF, fa/maxStd, maxF)
+	return ColorFA{rr, gg, bb, aa}
+}
+
+func NewPremulStd(c stdcolor.Color) PremulFA {
+	if p, ok := c.(StdPremulFA); ok {
+		return PremulFA(p)
+	}
+	r, g, b, a := c.RGBA()
+	return PremulFA{float32(r) / maxStd, float32(g) / maxStd, float32(b) / maxStd, float32(a) / maxStd}
+}
+
+func (c ColorFA) Std() StdFA {
+	return StdFA(c)
+}
+func (c ColorF) Std() StdF {
+	return StdF(c)
+}
+func (c Color64) Std() Std64 {
+	return Std64(c)
+}
+func (c Color48) Std() Std48 {
+	return Std48(c)
+}
+func (c Color32) Std() Std32 {
+	return Std32(c)
+}
+func (c Color24) Std() Std24 {
+	return Std24(c)
+}
+func (c Color16) Std() Std16 {
+	return Std16(c)
+}
+func (c Color8) Std() Std8 {
+	return Std8(c)
+}
+func (c PremulFA) Std() StdPremulFA {
+	return StdPremulFA(c)
+}
+
+/******************
+	STD_FA
+*******************/
+
+func (c StdFA) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	cc := ColorFA(c).Clamp()
+	aa := cc[3]
+	r = uint32(math.Round(cc[0] * aa * maxStd))
+	g = uint32(math.Round(cc[1] * aa * maxStd))
+	b = uint32(math.Round(cc[2] * aa * maxStd))
+	a = uint32(math.Round(aa * maxStd))
+	return r, g, b, a
+}
+
+/******************
+	STD_F
+*******************/
+
+func (c StdF) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	return StdFA(ColorF(c).ToColorFA()).RGBA()
+}
+
+/******************
+	STD_64
+*******************/
+
+func (c Std64) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	rr, gg, bb, aa := Color64(c).RGBA()
+	return stdcolor.NRGBA64{R: rr, G: gg, B: bb, A: aa}.RGBA()
+}
+
+/******************
+	STD_48
+*******************/
+
+func (c Std48) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	return uint32(c[0]), uint32(c[1]), uint32(c[2]), maxStd
+}
+
+/******************
+	STD_32
+*******************/
+
+func (c Std32) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	rr, gg, bb, aa := Color32(c).RGBA()
+	return stdcolor.NRGBA{R: rr, G: gg, B: bb, A: aa}.RGBA()
+}
+
+/******************
+	STD_24
+*******************/
+
+func (c Std24) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	r = uint32(c[0]) * 0x101
+	g = uint32(c[1]) * 0x101
+	b = uint32(c[2]) * 0x101
+	return r, g, b, maxStd
+}
+
+/******************
+	STD_16
+*******************/
+
+func (c Std16) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	rr, gg, bb, aa := Color16(c).RGBA()
+	return premulStd(uint32(rr)*0x1111, uint32(gg)*0x1111, uint32(bb)*0x1111, uint32(aa)*0x1111)
+}
+
+/******************
+	STD_8
+*******************/
+
+func (c Std8) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	rr, gg, bb, aa := Color8(c).RGBA()
+	return premulStd(uint32(rr)*0x5555, uint32(gg)*0x5555, uint32(bb)*0x5555, uint32(aa)*0x5555)
+}
+
+/******************
+	STD_PREMUL_FA
+*******************/
+
+func (c StdPremulFA) RGBA() (r uint32, g uint32, b uint32, a uint32) {
+	cc := PremulFA(c).Clamp()
+	r = uint32(math.Round(cc[0] * maxStd))
+	g = uint32(math.Round(cc[1] * maxStd))
+	b = uint32(math.Round(cc[2] * maxStd))
+	a = uint32(math.Round(cc[3] * maxStd))
+	return r, g, b, a
+}
+
+/******************
+	INTERNAL
+*******************/
+
+func premulStd(r uint32, g uint32, b uint32, a uint32) (uint32, uint32, uint32, uint32) {
+	r = r * a / maxStd
+	g = g * a / maxStd
+	b = b * a / maxStd
+	return r, g, b, a
+}
+
+func modelFA(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(StdFA); ok {
+		return c
+	}
+	return NewColorStd(c).Std()
+}
+
+func modelF(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(StdF); ok {
+		return c
+	}
+	r, g, b, _ := c.RGBA()
+	return StdF{float32(r) / maxStd, float32(g) / maxStd, float32(b) / maxStd}
+}
+
+func model64(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std64); ok {
+		return c
+	}
+	n := stdcolor.NRGBA64Model.Convert(c).(stdcolor.NRGBA64)
+	return Std64(nrgba64ToColor64(n))
+}
+
+func model48(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std48); ok {
+		return c
+	}
+	r, g, b, _ := c.RGBA()
+	return Std48{uint16(r), uint16(g), uint16(b)}
+}
+
+func model32(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std32); ok {
+		return c
+	}
+	n := stdcolor.NRGBAModel.Convert(c).(stdcolor.NRGBA)
+	return Std32(nrgbaToColor32(n))
+}
+
+func model24(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std24); ok {
+		return c
+	}
+	r, g, b, _ := c.RGBA()
+	return Std24{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+}
+
+func model16(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std16); ok {
+		return c
+	}
+	return NewColorStd(c).ToColor16().Std()
+}
+
+func model8(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(Std8); ok {
+		return c
+	}
+	return NewColorStd(c).ToColor8().Std()
+}
+
+func modelPremulFA(c stdcolor.Color) stdcolor.Color {
+	if _, ok := c.(StdPremulFA); ok {
+		return c
+	}
+	return NewPremulStd(c).Std()
+}
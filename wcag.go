@@ -0,0 +1,60 @@
+package color
+
+const (
+	WCAGAANormal   = 4.5
+	WCAGAALarge    = 3
+	WCAGAAANormal  = 7
+	WCAGAAALarge   = 4.5
+	WCAGAAGraphics = 3
+)
+
+// WCAGResult reports a WCAG 2.x contrast ratio and which success criteria it
+// passes: 1.4.3 and 1.4.6 for normal and large text, 1.4.11 for UI
+// components and graphical objects.
+type WCAGResult struct {
+	Ratio     float32
+	AANormal  bool
+	AALarge   bool
+	AAANormal bool
+	AAALarge  bool
+	AAUI      bool
+}
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) RelativeLuminance() float32 {
+	l := c.ToLinear()
+	return (l[0] * lumaR) + (l[1] * lumaG) + (l[2] * lumaB)
+}
+
+/******************
+	CONTRAST
+*******************/
+
+// ContrastRatio returns the WCAG 2.x contrast ratio of two opaque colors,
+// from 1 to 21. Alpha is ignored; use CheckContrast for translucent
+// foregrounds.
+func ContrastRatio(a ColorFA, b ColorFA) float32 {
+	la, lb := a.RelativeLuminance(), b.RelativeLuminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// CheckContrast composites fg over bg, treating bg as opaque, and rates
+// the resulting contrast against every WCAG 2.x threshold.
+func CheckContrast(fg ColorFA, bg ColorFA) WCAGResult {
+	bg = bg.SetAlpha(maxF)
+	ratio := ContrastRatio(bg.SrcOver(fg), bg)
+	return WCAGResult{
+		Ratio:     ratio,
+		AANormal:  ratio >= WCAGAANormal,
+		AALarge:   ratio >= WCAGAALarge,
+		AAANormal: ratio >= WCAGAAANormal,
+		AAALarge:  ratio >= WCAGAAALarge,
+		AAUI:      ratio >= WCAGAAGraphics,
+	}
+}
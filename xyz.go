@@ -0,0 +1,126 @@
+package color
+
+// XYZ holds CIE 1931 tristimulus values (Y of the reference white is 1)
+// followed by straight alpha.
+type XYZ [4]float32
+
+// WhitePoint is the XYZ of a reference white, normalized so Y is 1
+// (CIE 1931 2° observer).
+type WhitePoint [3]float32
+
+var (
+	WhiteA   = WhitePoint{1.09850, 1, 0.35585}
+	WhiteD50 = WhitePoint{0.96422, 1, 0.82521}
+	WhiteD65 = WhitePoint{0.95047, 1, 1.08883}
+	WhiteE   = WhitePoint{1, 1, 1}
+	WhiteF2  = WhitePoint{0.99187, 1, 0.67395}
+	WhiteF7  = WhitePoint{0.95044, 1, 1.08755}
+	WhiteF11 = WhitePoint{1.00966, 1, 0.64370}
+)
+
+/******************
+	WHITE_POINT
+*******************/
+
+func NewWhitePointXY(x float32, y float32) WhitePoint {
+	return WhitePoint{x / y, 1, (1 - x - y) / y}
+}
+
+func (w WhitePoint) XY() (x float32, y float32) {
+	sum := w[0] + w[1] + w[2]
+	return w[0] / sum, w[1] / sum
+}
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToXYZ(white WhitePoint) XYZ {
+	l := c.ToLinear()
+	x, y, z := srgbToXYZ.mul(l[0], l[1], l[2])
+	return XYZ{x, y, z, c[3]}.Adapt(WhiteD65, white)
+}
+
+/******************
+	XYZ
+*******************/
+
+func (c XYZ) ToColorFA(white WhitePoint) ColorFA {
+	d65 := c.Adapt(white, WhiteD65)
+	r, g, b := xyzToSRGB.mul(d65[0], d65[1], d65[2])
+	return LinearColorFA{r, g, b, c[3]}.ToColorFA()
+}
+
+func (c XYZ) X() float32 {
+	return c[0]
+}
+func (c XYZ) Y() float32 {
+	return c[1]
+}
+func (c XYZ) Z() float32 {
+	return c[2]
+}
+func (c XYZ) Alpha() float32 {
+	return c[3]
+}
+
+func (c XYZ) SetX(x float32) XYZ {
+	return XYZ{x, c[1], c[2], c[3]}
+}
+func (c XYZ) SetY(y float32) XYZ {
+	return XYZ{c[0], y, c[2], c[3]}
+}
+func (c XYZ) SetZ(z float32) XYZ {
+	return XYZ{c[0], c[1], z, c[3]}
+}
+func (c XYZ) SetAlpha(alpha float32) XYZ {
+	return XYZ{c[0], c[1], c[2], alpha}
+}
+
+// Adapt performs a Bradford chromatic adaptation from one reference white
+// to another.
+func (c XYZ) Adapt(from WhitePoint, to WhitePoint) XYZ {
+	if from == to {
+		return c
+	}
+	fr, fg, fb := bradford.mul(from[0], from[1], from[2])
+	tr, tg, tb := bradford.mul(to[0], to[1], to[2])
+	r, g, b := bradford.mul(c[0], c[1], c[2])
+	x, y, z := bradfordInv.mul(r*(tr/fr), g*(tg/fg), b*(tb/fb))
+	return XYZ{x, y, z, c[3]}
+}
+
+/******************
+	INTERNAL
+*******************/
+
+type matrix3 [3][3]float32
+
+var (
+	srgbToXYZ = matrix3{
+		{0.4124564, 0.3575761, 0.1804375},
+		{0.2126729, 0.7151522, 0.0721750},
+		{0.0193339, 0.1191920, 0.9503041},
+	}
+	xyzToSRGB = matrix3{
+		{3.2404542, -1.5371385, -0.4985314},
+		{-0.9692660, 1.8760108, 0.0415560},
+		{0.0556434, -0.2040259, 1.0572252},
+	}
+	bradford = matrix3{
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	}
+	bradfordInv = matrix3{
+		{0.9869929, -0.1470543, 0.1599627},
+		{0.4323053, 0.5183603, 0.0492912},
+		{-0.0085287, 0.0400428, 0.9684867},
+	}
+)
+
+func (m matrix3) mul(a float32, b float32, c float32) (float32, float32, float32) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
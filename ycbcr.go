@@ -0,0 +1,237 @@
+package color
+
+import (
+	math "github.com/gabe-lee/genmath"
+)
+
+const (
+	RangeFull YCbCrRange = iota
+	RangeLimited
+)
+
+const (
+	yuvUMax = 0.436
+	yuvVMax = 0.615
+	yiqRot  = 33
+)
+
+// YCbCrMatrix holds the red and blue luma coefficients of a video standard;
+// green is whatever remains.
+type YCbCrMatrix struct {
+	Kr float32
+	Kb float32
+}
+
+// YCbCrRange selects full (0-255) or limited/studio (16-235, 16-240)
+// quantization, expressed as fractions of 255.
+type YCbCrRange uint8
+
+// YCbCr holds normalized code values (chroma centered on 0.5) followed by
+// straight alpha.
+type YCbCr [4]float32
+
+// YUV holds analog Y'UV (U in ±0.436, V in ±0.615) followed by straight alpha.
+type YUV [4]float32
+
+// YIQ holds NTSC Y'IQ followed by straight alpha.
+type YIQ [4]float32
+
+// YCoCg holds Y, Co and Cg (chroma in ±0.5) followed by straight alpha.
+type YCoCg [4]float32
+
+// YCoCgR holds the lossless integer YCoCg-R transform of a Color32. Co and
+// Cg need one more bit than the source channels.
+type YCoCgR [4]int16
+
+var (
+	MatrixBT601  = YCbCrMatrix{0.299, 0.114}
+	MatrixBT709  = YCbCrMatrix{lumaR, lumaB}
+	MatrixBT2020 = YCbCrMatrix{0.2627, 0.0593}
+)
+
+/******************
+	YCBCR_MATRIX
+*******************/
+
+func (m YCbCrMatrix) Kg() float32 {
+	return 1 - m.Kr - m.Kb
+}
+
+func (m YCbCrMatrix) Luma(c ColorFA) float32 {
+	return (c[0] * m.Kr) + (c[1] * m.Kg()) + (c[2] * m.Kb)
+}
+
+func (m YCbCrMatrix) split(c ColorFA) (y float32, pb float32, pr float32) {
+	y = m.Luma(c)
+	pb = (c[2] - y) / (2 * (1 - m.Kb))
+	pr = (c[0] - y) / (2 * (1 - m.Kr))
+	return y, pb, pr
+}
+
+func (m YCbCrMatrix) join(y float32, pb float32, pr float32, a float32) ColorFA {
+	r := y + (2 * (1 - m.Kr) * pr)
+	b := y + (2 * (1 - m.Kb) * pb)
+	g := (y - (m.Kr * r) - (m.Kb * b)) / m.Kg()
+	return ColorFA{r, g, b, a}.Clamp()
+}
+
+/******************
+	COLOR_FA
+*******************/
+
+func (c ColorFA) ToYCbCr(m YCbCrMatrix, rng YCbCrRange) YCbCr {
+	y, pb, pr := m.split(c)
+	if rng == RangeLimited {
+		return YCbCr{(16 + (219 * y)) / max32, (128 + (224 * pb)) / max32, (128 + (224 * pr)) / max32, c[3]}
+	}
+	return YCbCr{y, pb + 0.5, pr + 0.5, c[3]}
+}
+
+func (c ColorFA) ToYUV(m YCbCrMatrix) YUV {
+	y, pb, pr := m.split(c)
+	return YUV{y, 2 * yuvUMax * pb, 2 * yuvVMax * pr, c[3]}
+}
+
+func (c ColorFA) ToYIQ() YIQ {
+	yuv := c.ToYUV(MatrixBT601)
+	sin, cos := math.SinDeg(float32(yiqRot)), math.CosDeg(float32(yiqRot))
+	i := (yuv[2] * cos) - (yuv[1] * sin)
+	q := (yuv[2] * sin) + (yuv[1] * cos)
+	return YIQ{yuv[0], i, q, c[3]}
+}
+
+func (c ColorFA) ToYCoCg() YCoCg {
+	y := (c[0] / 4) + (c[1] / 2) + (c[2] / 4)
+	co := (c[0] / 2) - (c[2] / 2)
+	cg := (c[1] / 2) - (c[0] / 4) - (c[2] / 4)
+	return YCoCg{y, co, cg, c[3]}
+}
+
+/******************
+	YCBCR
+*******************/
+
+func (c YCbCr) ToColorFA(m YCbCrMatrix, rng YCbCrRange) ColorFA {
+	if rng == RangeLimited {
+		return m.join(((c[0]*max32)-16)/219, ((c[1]*max32)-128)/224, ((c[2]*max32)-128)/224, c[3])
+	}
+	return m.join(c[0], c[1]-0.5, c[2]-0.5, c[3])
+}
+
+func (c YCbCr) Y() float32 {
+	return c[0]
+}
+func (c YCbCr) Cb() float32 {
+	return c[1]
+}
+func (c YCbCr) Cr() float32 {
+	return c[2]
+}
+func (c YCbCr) Alpha() float32 {
+	return c[3]
+}
+
+/******************
+	YUV
+*******************/
+
+func (c YUV) ToColorFA(m YCbCrMatrix) ColorFA {
+	return m.join(c[0], c[1]/(2*yuvUMax), c[2]/(2*yuvVMax), c[3])
+}
+
+func (c YUV) Y() float32 {
+	return c[0]
+}
+func (c YUV) U() float32 {
+	return c[1]
+}
+func (c YUV) V() float32 {
+	return c[2]
+}
+func (c YUV) Alpha() float32 {
+	return c[3]
+}
+
+/******************
+	YIQ
+*******************/
+
+func (c YIQ) ToColorFA() ColorFA {
+	sin, cos := math.SinDeg(float32(yiqRot)), math.CosDeg(float32(yiqRot))
+	u := (c[2] * cos) - (c[1] * sin)
+	v := (c[1] * cos) + (c[2] * sin)
+	return YUV{c[0], u, v, c[3]}.ToColorFA(MatrixBT601)
+}
+
+func (c YIQ) Y() float32 {
+	return c[0]
+}
+func (c YIQ) I() float32 {
+	return c[1]
+}
+func (c YIQ) Q() float32 {
+	return c[2]
+}
+func (c YIQ) Alpha() float32 {
+	return c[3]
+}
+
+/******************
+	YCOCG
+*******************/
+
+func (c YCoCg) ToColorFA() ColorFA {
+	t := c[0] - c[2]
+	return ColorFA{t + c[1], c[0] + c[2], t - c[1], c[3]}.Clamp()
+}
+
+func (c YCoCg) Y() float32 {
+	return c[0]
+}
+func (c YCoCg) Co() float32 {
+	return c[1]
+}
+func (c YCoCg) Cg() float32 {
+	return c[2]
+}
+func (c YCoCg) Alpha() float32 {
+	return c[3]
+}
+
+/******************
+	COLOR_32
+*******************/
+
+func (c Color32) ToYCoCgR() YCoCgR {
+	r, g, b, a := c.RGBA()
+	co := int16(r) - int16(b)
+	t := int16(b) + (co >> 1)
+	cg := int16(g) - t
+	y := t + (cg >> 1)
+	return YCoCgR{y, co, cg, int16(a)}
+}
+
+/******************
+	YCOCG_R
+*******************/
+
+func (c YCoCgR) ToColor32() Color32 {
+	t := c[0] - (c[2] >> 1)
+	g := c[2] + t
+	b := t - (c[1] >> 1)
+	r := b + c[1]
+	return Color32(uint8(r))<<24 | Color32(uint8(g))<<16 | Color32(uint8(b))<<8 | Color32(uint8(c[3]))
+}
+
+func (c YCoCgR) Y() int16 {
+	return c[0]
+}
+func (c YCoCgR) Co() int16 {
+	return c[1]
+}
+func (c YCoCgR) Cg() int16 {
+	return c[2]
+}
+func (c YCoCgR) Alpha() int16 {
+	return c[3]
+}